@@ -0,0 +1,242 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+)
+
+// ArchiveOptions holds the inputs for `oc archive project`.
+type ArchiveOptions struct {
+	genericclioptions.IOStreams
+
+	Namespace  string
+	OutputPath string
+	Include    []string
+	Exclude    []string
+
+	dynamicClient dynamic.Interface
+	resources     []schema.GroupVersionResource
+}
+
+// NewArchiveOptions returns an ArchiveOptions with IOStreams populated.
+func NewArchiveOptions(streams genericclioptions.IOStreams) *ArchiveOptions {
+	return &ArchiveOptions{IOStreams: streams}
+}
+
+// Complete resolves the namespace to snapshot, the client used to read it,
+// and the set of namespaced resource types the server supports.
+func (o *ArchiveOptions) Complete(f kcmdutil.Factory, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one argument is required: the project to archive")
+	}
+	o.Namespace = args[0]
+
+	if len(o.OutputPath) == 0 {
+		o.OutputPath = o.Namespace + ".tar.gz"
+	}
+
+	var err error
+	o.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	discoveryClient, err := f.ToDiscoveryClient()
+	if err != nil {
+		return err
+	}
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil && len(resourceLists) == 0 {
+		return err
+	}
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if !strings.Contains(r.Verbs.String(), "list") {
+				continue
+			}
+			o.resources = append(o.resources, gv.WithResource(r.Name))
+		}
+	}
+	return nil
+}
+
+// Validate sanity-checks the resolved options.
+func (o *ArchiveOptions) Validate() error {
+	if len(o.Namespace) == 0 {
+		return fmt.Errorf("a project name is required")
+	}
+	return nil
+}
+
+// namespaceGVR and crdGVR are the two cluster-scoped resources Run also
+// collects, alongside the namespaced resources discovered in Complete -
+// see dependencyGroup's Namespace and CustomResourceDefinition tiers.
+var (
+	namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	crdGVR       = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+)
+
+// Run walks every namespaced resource type the server supports, scrubs and
+// collects the ones the filter allows, and writes them into a self
+// -describing tarball at OutputPath.
+func (o *ArchiveOptions) Run() error {
+	filter := NewDefaultResourceFilter()
+	for _, k := range o.Include {
+		if filter.Allow == nil {
+			filter.Allow = map[string]bool{}
+		}
+		filter.Allow[k] = true
+	}
+	for _, k := range o.Exclude {
+		filter.Deny[k] = true
+	}
+
+	f, err := os.Create(o.OutputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	manifest := Manifest{
+		APIVersion: ManifestAPIVersion,
+		Kind:       ManifestKind,
+		Namespace:  o.Namespace,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// The project's own Namespace object is cluster-scoped and so isn't
+	// among o.resources (ServerPreferredNamespacedResources only), but
+	// dependencyGroup still needs it captured to have anything to apply
+	// first at restore time.
+	if ns, err := o.dynamicClient.Resource(namespaceGVR).Get(context.TODO(), o.Namespace, metav1.GetOptions{}); err == nil {
+		if err := o.addResource(tw, &manifest, ns); err != nil {
+			return err
+		}
+	}
+
+	seenCRDs := map[string]bool{}
+
+	for _, gvr := range o.resources {
+		list, err := o.dynamicClient.Resource(gvr).Namespace(o.Namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			// Servers routinely advertise resources a given user can't
+			// list in this namespace (e.g. cluster-scoped aggregated APIs
+			// misreported as namespaced); skip rather than fail the whole
+			// archive.
+			continue
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			if !filter.Includes(item.GetKind()) {
+				continue
+			}
+
+			if len(gvr.Group) > 0 {
+				o.addCRD(tw, &manifest, gvr, seenCRDs)
+			}
+
+			if err := o.addResource(tw, &manifest, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, manifestFileName, manifestData); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Archived %d resources from project %q to %s\n", len(manifest.Resources), o.Namespace, o.OutputPath)
+	return nil
+}
+
+// addResource scrubs item, writes it into the tarball, and records it in
+// manifest.
+func (o *ArchiveOptions) addResource(tw *tar.Writer, manifest *Manifest, item *unstructured.Unstructured) error {
+	scrub(item)
+
+	gvk := item.GroupVersionKind()
+	ref := ResourceRef{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind,
+		Name:    item.GetName(),
+		File:    fmt.Sprintf("resources/%s/%s.yaml", gvkDirName(gvk), sanitizeFileName(item.GetName())),
+	}
+
+	data, err := yaml.Marshal(item.Object)
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, ref.File, data); err != nil {
+		return err
+	}
+	manifest.Resources = append(manifest.Resources, ref)
+	return nil
+}
+
+// addCRD best-effort fetches and archives the CustomResourceDefinition
+// backing gvr, if one exists - built-in aggregated APIs (apps, rbac, ...)
+// share the same discovery shape as CRDs but have no matching
+// CustomResourceDefinition object, so a NotFound here is expected and
+// silently skipped rather than failing the archive. seen dedupes repeat
+// lookups for the same resource across namespaces worth of items.
+func (o *ArchiveOptions) addCRD(tw *tar.Writer, manifest *Manifest, gvr schema.GroupVersionResource, seen map[string]bool) {
+	name := gvr.Resource + "." + gvr.Group
+	if seen[name] {
+		return
+	}
+	seen[name] = true
+
+	crd, err := o.dynamicClient.Resource(crdGVR).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+	_ = o.addResource(tw, manifest, crd)
+}
+
+func gvkDirName(gvk schema.GroupVersionKind) string {
+	group := gvk.Group
+	if len(group) == 0 {
+		group = "core"
+	}
+	return fmt.Sprintf("%s_%s_%s", group, gvk.Version, gvk.Kind)
+}
+
+func sanitizeFileName(name string) string {
+	return strings.ReplaceAll(name, "/", "_")
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
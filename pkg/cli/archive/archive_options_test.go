@@ -0,0 +1,125 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+)
+
+var widgetGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func newUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+// TestArchiveOptionsRunCapturesNamespaceAndCRD exercises the gap
+// dependencyGroup's Namespace and CustomResourceDefinition tiers used to be
+// unreachable for: Run should now also pick up the project's own Namespace
+// object and the CustomResourceDefinition backing a custom resource found
+// in it, not just the namespaced resources discovery returns.
+func TestArchiveOptionsRunCapturesNamespaceAndCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}:                                              "NamespaceList",
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}: "CustomResourceDefinitionList",
+		widgetGVR: "WidgetList",
+	}
+
+	namespaceObj := newUnstructured("v1", "Namespace", "", "demo")
+	crdObj := newUnstructured("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.example.com")
+	widgetObj := newUnstructured("example.com/v1", "Widget", "demo", "my-widget")
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, namespaceObj, crdObj, widgetObj)
+
+	dir := t.TempDir()
+	o := &ArchiveOptions{
+		IOStreams:     genericclioptions.IOStreams{Out: io.Discard, ErrOut: io.Discard},
+		Namespace:     "demo",
+		OutputPath:    filepath.Join(dir, "demo.tar.gz"),
+		dynamicClient: client,
+		resources:     []schema.GroupVersionResource{widgetGVR},
+	}
+
+	if err := o.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	manifest, kinds := readArchivedKinds(t, o.OutputPath)
+
+	if manifest.Namespace != "demo" {
+		t.Errorf("manifest.Namespace = %q, want demo", manifest.Namespace)
+	}
+	for _, want := range []string{"Namespace", "CustomResourceDefinition", "Widget"} {
+		if !kinds[want] {
+			t.Errorf("archive is missing a %s resource; got kinds %v", want, kinds)
+		}
+	}
+
+	for _, ref := range manifest.Resources {
+		if ref.Kind == "Namespace" && dependencyGroup(ref.GroupVersionKind()) != 0 {
+			t.Errorf("Namespace dependencyGroup = %d, want 0", dependencyGroup(ref.GroupVersionKind()))
+		}
+		if ref.Kind == "CustomResourceDefinition" && dependencyGroup(ref.GroupVersionKind()) != 2 {
+			t.Errorf("CustomResourceDefinition dependencyGroup = %d, want 2", dependencyGroup(ref.GroupVersionKind()))
+		}
+	}
+}
+
+func readArchivedKinds(t *testing.T, path string) (Manifest, map[string]bool) {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gz.Close()
+
+	var manifest Manifest
+	kinds := map[string]bool{}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar.Next: %v", err)
+		}
+		if hdr.Name == manifestFileName {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatalf("reading manifest: %v", err)
+			}
+			if err := yaml.Unmarshal(data, &manifest); err != nil {
+				t.Fatalf("unmarshaling manifest: %v", err)
+			}
+			continue
+		}
+	}
+	for _, ref := range manifest.Resources {
+		kinds[ref.Kind] = true
+	}
+	return manifest, kinds
+}
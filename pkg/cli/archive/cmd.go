@@ -0,0 +1,82 @@
+package archive
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+var archiveExample = ktemplates.Examples(`
+	# Snapshot a project to project.tar.gz
+	oc archive project myproject -o project.tar.gz
+
+	# Snapshot a project, excluding Jobs
+	oc archive project myproject --exclude=Job
+`)
+
+// NewCmdArchive implements `oc archive`, the parent of `oc archive project`.
+func NewCmdArchive(f kcmdutil.Factory, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Snapshot a project for later restore",
+	}
+	cmd.AddCommand(NewCmdArchiveProject(f, streams))
+	return cmd
+}
+
+// NewCmdArchiveProject implements `oc archive project`.
+func NewCmdArchiveProject(f kcmdutil.Factory, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewArchiveOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "project NAME",
+		Short:   "Snapshot every namespaced resource in a project into a tarball",
+		Example: archiveExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+	cmd.Flags().StringVarP(&o.OutputPath, "output", "o", "", "Path of the archive to write (defaults to <project>.tar.gz)")
+	cmd.Flags().StringSliceVar(&o.Include, "include", nil, "Only archive these resource kinds")
+	cmd.Flags().StringSliceVar(&o.Exclude, "exclude", nil, "Never archive these resource kinds, in addition to the built-in deny list")
+	return cmd
+}
+
+var restoreExample = ktemplates.Examples(`
+	# Restore a project archive into the project it was taken from
+	oc restore -f project.tar.gz
+
+	# Restore a project archive into a different project
+	oc restore -f project.tar.gz --namespace myproject-copy
+`)
+
+// NewCmdRestore implements `oc restore`.
+func NewCmdRestore(f kcmdutil.Factory, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewRestoreOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "restore",
+		Short:   "Replay a project archive produced by oc archive project",
+		Example: restoreExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+	cmd.Flags().StringVarP(&o.ArchivePath, "filename", "f", "", "Archive to restore")
+	cmd.Flags().StringVar(&o.Namespace, "namespace", "", "Project to restore into (defaults to the project recorded in the archive)")
+	return cmd
+}
@@ -0,0 +1,54 @@
+// Package archive implements `oc archive project` and `oc restore`, a
+// project-level snapshot and replay mechanism built on server-side apply.
+package archive
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// manifestFileName is the name of the self-describing manifest written at
+// the root of every archive produced by `oc archive project`.
+const manifestFileName = "manifest.yaml"
+
+// Manifest is the self-describing index written to manifest.yaml at the
+// root of an archive. It lets `oc restore` apply resources in dependency
+// order without re-deriving it from the files on disk.
+type Manifest struct {
+	// APIVersion/Kind let `oc restore` reject archives from incompatible
+	// future tooling instead of failing confusingly partway through.
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string `json:"kind" yaml:"kind"`
+
+	// Namespace is the project the archive was taken from; `oc restore
+	// --namespace` overrides it at restore time.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// CreatedAt is an RFC3339 timestamp, informational only.
+	CreatedAt string `json:"createdAt" yaml:"createdAt"`
+
+	// Resources lists every object written into the archive, in the order
+	// they were collected (not necessarily apply order - see
+	// dependencyGroup).
+	Resources []ResourceRef `json:"resources" yaml:"resources"`
+}
+
+// ManifestAPIVersion/ManifestKind are the expected Manifest.APIVersion and
+// Manifest.Kind; oc restore rejects anything else.
+const (
+	ManifestAPIVersion = "archive.oc.openshift.io/v1"
+	ManifestKind       = "ProjectArchive"
+)
+
+// ResourceRef identifies one object and the file inside the archive holding
+// its YAML.
+type ResourceRef struct {
+	Group   string `json:"group" yaml:"group"`
+	Version string `json:"version" yaml:"version"`
+	Kind    string `json:"kind" yaml:"kind"`
+	Name    string `json:"name" yaml:"name"`
+	// File is the path inside the tarball, e.g.
+	// "resources/apps_v1_Deployment/myapp.yaml".
+	File string `json:"file" yaml:"file"`
+}
+
+// GroupVersionKind returns r's GVK.
+func (r ResourceRef) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: r.Group, Version: r.Version, Kind: r.Kind}
+}
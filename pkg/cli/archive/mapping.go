@@ -0,0 +1,44 @@
+package archive
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// restMapping is the subset of *meta.RESTMapping RestoreOptions needs to
+// apply an object.
+type restMapping struct {
+	resource   schema.GroupVersionResource
+	namespaced bool
+}
+
+// factoryMapper adapts a kcmdutil.Factory's RESTMapper to the restMapper
+// interface so RestoreOptions doesn't depend on *meta.RESTMapper directly.
+type factoryMapper struct {
+	f kcmdutil.Factory
+}
+
+func (m *factoryMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*restMapping, error) {
+	restMapper, err := m.f.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := restMapper.RESTMapping(gk, versions...)
+	if err != nil {
+		return nil, err
+	}
+	return &restMapping{
+		resource:   mapping.Resource,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}, nil
+}
+
+const applyPatchType = types.ApplyPatchType
+
+func applyPatchOptions() metav1.PatchOptions {
+	force := true
+	return metav1.PatchOptions{FieldManager: fieldManager, Force: &force}
+}
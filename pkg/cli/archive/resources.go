@@ -0,0 +1,62 @@
+package archive
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// defaultDenyKinds are namespaced resource kinds that are never useful to
+// snapshot: generated or cluster-derived objects that don't round-trip
+// (Events, Endpoints, Pods, controller-owned ReplicaSets, ...).
+var defaultDenyKinds = map[string]bool{
+	"Event":                 true,
+	"Endpoints":             true,
+	"EndpointSlice":         true,
+	"ReplicaSet":            true,
+	"ControllerRevision":    true,
+	"Pod":                   true,
+	"Build":                 true,
+	"ReplicationController": true,
+}
+
+// ResourceFilter decides whether a kind is included in an archive. The zero
+// value includes every kind; NewDefaultResourceFilter starts from
+// defaultDenyKinds, and --include/--exclude on `oc archive project` layer
+// Allow/Deny on top of that.
+type ResourceFilter struct {
+	Allow map[string]bool
+	Deny  map[string]bool
+}
+
+// NewDefaultResourceFilter returns the filter `oc archive project` uses
+// when --include/--exclude aren't given.
+func NewDefaultResourceFilter() ResourceFilter {
+	deny := map[string]bool{}
+	for k, v := range defaultDenyKinds {
+		deny[k] = v
+	}
+	return ResourceFilter{Deny: deny}
+}
+
+// Includes returns whether kind should be archived.
+func (f ResourceFilter) Includes(kind string) bool {
+	if len(f.Allow) > 0 {
+		return f.Allow[kind]
+	}
+	return !f.Deny[kind]
+}
+
+// dependencyGroup buckets a GVK for ordering at restore time: lower-numbered
+// groups are applied first. Anything not matched here is a workload and
+// applied last, after everything else.
+func dependencyGroup(gvk schema.GroupVersionKind) int {
+	switch {
+	case gvk.Kind == "Namespace":
+		return 0
+	case gvk.Group == "rbac.authorization.k8s.io":
+		return 1
+	case gvk.Kind == "CustomResourceDefinition":
+		return 2
+	case gvk.Kind == "Secret" || gvk.Kind == "ConfigMap":
+		return 3
+	default:
+		return 4
+	}
+}
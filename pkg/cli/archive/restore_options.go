@@ -0,0 +1,189 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+)
+
+const fieldManager = "oc-restore"
+
+// RestoreOptions holds the inputs for `oc restore`.
+type RestoreOptions struct {
+	genericclioptions.IOStreams
+
+	ArchivePath string
+	Namespace   string
+
+	dynamicClient dynamic.Interface
+	mapper        restMapper
+}
+
+// restMapper is the subset of meta.RESTMapper RestoreOptions needs; defined
+// locally so tests can supply a fake.
+type restMapper interface {
+	RESTMapping(gk schema.GroupKind, versions ...string) (*restMapping, error)
+}
+
+// NewRestoreOptions returns a RestoreOptions with IOStreams populated.
+func NewRestoreOptions(streams genericclioptions.IOStreams) *RestoreOptions {
+	return &RestoreOptions{IOStreams: streams}
+}
+
+// Complete resolves the client used to apply the archive. If --namespace
+// wasn't given, the namespace recorded in the archive's manifest is used.
+func (o *RestoreOptions) Complete(f kcmdutil.Factory, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("oc restore takes no positional arguments; pass the archive with -f")
+	}
+	if len(o.ArchivePath) == 0 {
+		return fmt.Errorf("-f is required")
+	}
+
+	var err error
+	o.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return err
+	}
+	o.mapper = &factoryMapper{f}
+	return nil
+}
+
+// Validate sanity-checks the resolved options.
+func (o *RestoreOptions) Validate() error {
+	if _, err := os.Stat(o.ArchivePath); err != nil {
+		return fmt.Errorf("unable to find archive %q: %v", o.ArchivePath, err)
+	}
+	return nil
+}
+
+// Run reads every resource out of the archive, scrubs and retargets its
+// namespace, sorts by dependencyGroup (Namespaces, then RBAC, then CRDs,
+// then everything else), and applies each one via server-side apply in that
+// order.
+func (o *RestoreOptions) Run() error {
+	objects, manifest, err := o.readArchive()
+	if err != nil {
+		return err
+	}
+
+	namespace := o.Namespace
+	if len(namespace) == 0 {
+		namespace = manifest.Namespace
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return dependencyGroup(objects[i].GroupVersionKind()) < dependencyGroup(objects[j].GroupVersionKind())
+	})
+
+	for _, obj := range objects {
+		if err := o.apply(obj, namespace); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "%s/%s applied\n", obj.GetKind(), obj.GetName())
+	}
+	return nil
+}
+
+func (o *RestoreOptions) readArchive() ([]*unstructured.Unstructured, Manifest, error) {
+	f, err := os.Open(o.ArchivePath)
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	var objects []*unstructured.Unstructured
+	var manifest Manifest
+	sawManifest := false
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, Manifest{}, err
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, Manifest{}, err
+		}
+
+		if hdr.Name == manifestFileName {
+			if err := yaml.Unmarshal(data, &manifest); err != nil {
+				return nil, Manifest{}, fmt.Errorf("unable to parse %s: %v", manifestFileName, err)
+			}
+			sawManifest = true
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &u.Object); err != nil {
+			return nil, Manifest{}, fmt.Errorf("unable to parse %s: %v", hdr.Name, err)
+		}
+		objects = append(objects, u)
+	}
+
+	if !sawManifest {
+		return nil, Manifest{}, fmt.Errorf("archive is missing %s; not a project archive produced by oc archive project", manifestFileName)
+	}
+	if manifest.APIVersion != ManifestAPIVersion || manifest.Kind != ManifestKind {
+		return nil, Manifest{}, fmt.Errorf("unsupported archive manifest %s/%s", manifest.APIVersion, manifest.Kind)
+	}
+
+	return objects, manifest, nil
+}
+
+// apply server-side applies obj. Namespaced objects are reparented onto
+// namespace; the archived Namespace object itself is cluster-scoped, so it
+// is instead renamed to namespace, which is what actually creates the
+// retargeted project when --namespace overrides the name recorded in the
+// archive.
+func (o *RestoreOptions) apply(obj *unstructured.Unstructured, namespace string) error {
+	gvk := obj.GroupVersionKind()
+	mapping, err := o.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("unable to resolve %s %q: %v", gvk.Kind, obj.GetName(), err)
+	}
+
+	var resourceClient dynamic.ResourceInterface
+	if mapping.namespaced {
+		obj.SetNamespace(namespace)
+		resourceClient = o.dynamicClient.Resource(mapping.resource).Namespace(namespace)
+	} else {
+		if gvk.Kind == "Namespace" && gvk.Group == "" {
+			obj.SetName(namespace)
+		}
+		resourceClient = o.dynamicClient.Resource(mapping.resource)
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = resourceClient.Patch(context.TODO(), obj.GetName(), applyPatchType, data, applyPatchOptions())
+	if err != nil {
+		return fmt.Errorf("unable to apply %s %q: %v", gvk.Kind, obj.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// fakeRestMapper is a restMapper that knows only the two GVKs the tests
+// below need: the core Namespace and the example.com Widget.
+type fakeRestMapper struct{}
+
+func (fakeRestMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*restMapping, error) {
+	version := "v1"
+	if len(versions) > 0 {
+		version = versions[0]
+	}
+	switch gk.Kind {
+	case "Namespace":
+		return &restMapping{resource: namespaceGVR, namespaced: false}, nil
+	case "Widget":
+		return &restMapping{
+			resource:   schema.GroupVersionResource{Group: gk.Group, Version: version, Resource: "widgets"},
+			namespaced: true,
+		}, nil
+	}
+	return nil, fmt.Errorf("no RESTMapping for %s", gk)
+}
+
+// TestRestoreOptionsRunRetargetsNamespace exercises --namespace overriding
+// the project name recorded in the archive: the archived Namespace object
+// must be renamed to the override, not just the namespaced objects that
+// follow it, or the target project is never actually created.
+func TestRestoreOptionsRunRetargetsNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		namespaceGVR: "NamespaceList",
+		crdGVR:       "CustomResourceDefinitionList",
+		widgetGVR:    "WidgetList",
+	}
+
+	namespaceObj := newUnstructured("v1", "Namespace", "", "demo")
+	widgetObj := newUnstructured("example.com/v1", "Widget", "demo", "my-widget")
+
+	archiveClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, namespaceObj, widgetObj)
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "demo.tar.gz")
+	archiver := &ArchiveOptions{
+		IOStreams:     genericclioptions.IOStreams{Out: io.Discard, ErrOut: io.Discard},
+		Namespace:     "demo",
+		OutputPath:    archivePath,
+		dynamicClient: archiveClient,
+		resources:     []schema.GroupVersionResource{widgetGVR},
+	}
+	if err := archiver.Run(); err != nil {
+		t.Fatalf("Run (archive): %v", err)
+	}
+
+	restoreClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+	restorer := &RestoreOptions{
+		IOStreams:     genericclioptions.IOStreams{Out: io.Discard, ErrOut: io.Discard},
+		ArchivePath:   archivePath,
+		Namespace:     "myproject-copy",
+		dynamicClient: restoreClient,
+		mapper:        fakeRestMapper{},
+	}
+	if err := restorer.Run(); err != nil {
+		t.Fatalf("Run (restore): %v", err)
+	}
+
+	if _, err := restoreClient.Resource(namespaceGVR).Get(context.TODO(), "myproject-copy", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected Namespace %q to be created: %v", "myproject-copy", err)
+	}
+	if _, err := restoreClient.Resource(namespaceGVR).Get(context.TODO(), "demo", metav1.GetOptions{}); err == nil {
+		t.Errorf("did not expect the archived Namespace's original name %q to be created", "demo")
+	}
+
+	widget, err := restoreClient.Resource(widgetGVR).Namespace("myproject-copy").Get(context.TODO(), "my-widget", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected Widget to be restored into %q: %v", "myproject-copy", err)
+	}
+	if widget.GetNamespace() != "myproject-copy" {
+		t.Errorf("Widget namespace = %q, want %q", widget.GetNamespace(), "myproject-copy")
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
 	kubecmd "k8s.io/kubectl/pkg/cmd"
 	"k8s.io/kubectl/pkg/cmd/plugin"
 	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -21,13 +22,16 @@ import (
 	kterm "k8s.io/kubectl/pkg/util/term"
 
 	"github.com/openshift/oc/pkg/cli/admin"
+	"github.com/openshift/oc/pkg/cli/archive"
 	"github.com/openshift/oc/pkg/cli/cancelbuild"
 	"github.com/openshift/oc/pkg/cli/debug"
 	"github.com/openshift/oc/pkg/cli/deployer"
+	"github.com/openshift/oc/pkg/cli/dockerbuild"
 	"github.com/openshift/oc/pkg/cli/expose"
 	"github.com/openshift/oc/pkg/cli/extract"
 	"github.com/openshift/oc/pkg/cli/idle"
 	"github.com/openshift/oc/pkg/cli/image"
+	"github.com/openshift/oc/pkg/cli/importer"
 	"github.com/openshift/oc/pkg/cli/importimage"
 	"github.com/openshift/oc/pkg/cli/kubectlwrappers"
 	"github.com/openshift/oc/pkg/cli/login"
@@ -37,6 +41,7 @@ import (
 	"github.com/openshift/oc/pkg/cli/newbuild"
 	"github.com/openshift/oc/pkg/cli/observe"
 	"github.com/openshift/oc/pkg/cli/options"
+	ocplugin "github.com/openshift/oc/pkg/cli/plugin"
 	"github.com/openshift/oc/pkg/cli/policy"
 	"github.com/openshift/oc/pkg/cli/process"
 	"github.com/openshift/oc/pkg/cli/project"
@@ -54,7 +59,9 @@ import (
 	"github.com/openshift/oc/pkg/cli/startbuild"
 	"github.com/openshift/oc/pkg/cli/status"
 	"github.com/openshift/oc/pkg/cli/tag"
+	"github.com/openshift/oc/pkg/cli/telemetry"
 	"github.com/openshift/oc/pkg/cli/version"
+	"github.com/openshift/oc/pkg/cli/warnings"
 	"github.com/openshift/oc/pkg/cli/whoami"
 )
 
@@ -107,6 +114,13 @@ func NewDefaultOcCommand(in io.Reader, out, errout io.Writer) *cobra.Command {
 	cmdPathPieces := os.Args[1:]
 	pluginHandler := kubecmd.NewDefaultPluginHandler(plugin.ValidPluginFilenamePrefixes)
 
+	// plugins installed via `oc plugin install` live under ~/.oc/plugins/bin;
+	// prepend it to PATH so the plugin handler's lookup finds them ahead of
+	// anything a user has separately placed on PATH.
+	if binDir, err := ocplugin.BinDir(); err == nil {
+		os.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	}
+
 	// only look for suitable extension executables if
 	// the specified command does not already exist
 	if _, _, err := cmd.Find(cmdPathPieces); err != nil {
@@ -136,49 +150,126 @@ func NewDefaultOcCommand(in io.Reader, out, errout io.Writer) *cobra.Command {
 }
 
 func NewOcCommand(in io.Reader, out, err io.Writer) *cobra.Command {
-	warningHandler := rest.NewWarningWriter(err, rest.WarningWriterOptions{Deduplicate: true, Color: kterm.AllowsColorOutput(err)})
+	textWarningHandler := rest.NewWarningWriter(err, rest.WarningWriterOptions{Deduplicate: true, Color: kterm.AllowsColorOutput(err)})
+	warningAggregator := warnings.NewAggregator()
 	warningsAsErrors := false
+	warningsOutput := "text"
+	warningsFile := ""
+	var warningsFileHandle *os.File
+
+	telemetryObserver := telemetry.NewObserver()
+	var invocation *telemetry.Invocation
+
 	// Main command
 	cmds := &cobra.Command{
 		Use:   "oc",
 		Short: "Command line tools for managing applications",
 		Long:  cliLong,
 		Run:   runHelp,
-		PersistentPreRunE: func(*cobra.Command, []string) error {
-			rest.SetDefaultWarningHandler(warningHandler)
-			return nil
-		},
-		PersistentPostRunE: func(*cobra.Command, []string) error {
-			if warningsAsErrors {
-				count := warningHandler.WarningCount()
-				switch count {
-				case 0:
-					// no warnings
-				case 1:
-					return fmt.Errorf("%d warning received", count)
-				default:
-					return fmt.Errorf("%d warnings received", count)
-				}
-			}
-			return nil
-		},
 	}
 
 	flags := cmds.PersistentFlags()
 	flags.BoolVar(&warningsAsErrors, "warnings-as-errors", warningsAsErrors, "Treat warnings received from the server as errors and exit with a non-zero exit code")
+	flags.StringVar(&warningsOutput, "warnings-output", warningsOutput, "Format for warnings emitted at exit: text, json, or yaml")
+	flags.StringVar(&warningsFile, "warnings-file", warningsFile, "If set, write warnings to this file instead of stderr")
 
 	kubeConfigFlags := genericclioptions.NewConfigFlags(true).WithDiscoveryBurst(350).WithDiscoveryQPS(50.0)
+	kubeConfigFlags.WrapConfigFn = func(cfg *rest.Config) *rest.Config {
+		cfg.WrapTransport = transport.Wrappers(cfg.WrapTransport, warnings.WrapTransport)
+		return cfg
+	}
 	kubeConfigFlags.AddFlags(flags)
 	matchVersionKubeConfigFlags := kcmdutil.NewMatchVersionFlags(kubeConfigFlags)
 	matchVersionKubeConfigFlags.AddFlags(cmds.PersistentFlags())
 	cmds.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 	f := kcmdutil.NewFactory(matchVersionKubeConfigFlags)
 
+	// f must exist before these hooks are wired up, since PersistentPostRunE
+	// resolves the server version through it - assigned here rather than in
+	// the cobra.Command literal above.
+	cmds.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		invocation = telemetryObserver.Start(cmd, f)
+
+		if !warnings.IsValidOutputFormat(warningsOutput) {
+			return fmt.Errorf("invalid --warnings-output %q, must be one of: %s", warningsOutput, strings.Join(warnings.ValidOutputFormats, ", "))
+		}
+
+		handlers := []rest.WarningHandler{warningAggregator}
+		if warningsOutput == "text" && len(warningsFile) == 0 {
+			handlers = append(handlers, textWarningHandler)
+		}
+		rest.SetDefaultWarningHandlerWithContext(warnings.NewMultiHandler(handlers...))
+		return nil
+	}
+	cmds.PersistentPostRunE = func(cmd *cobra.Command, args []string) (resultErr error) {
+		defer func() {
+			serverVersion := ""
+			if telemetryObserver.Active() {
+				if discoveryClient, err := f.ToDiscoveryClient(); err == nil {
+					if version, err := discoveryClient.ServerVersion(); err == nil {
+						serverVersion = version.GitVersion
+					}
+				}
+			}
+			exitCode := 0
+			if resultErr != nil {
+				exitCode = 1
+			}
+			invocation.Finish(exitCode, serverVersion, warningAggregator.Len())
+		}()
+
+		if len(warningsFile) > 0 {
+			fileHandle, openErr := os.Create(warningsFile)
+			if openErr != nil {
+				return fmt.Errorf("unable to open --warnings-file %q: %v", warningsFile, openErr)
+			}
+			warningsFileHandle = fileHandle
+			defer warningsFileHandle.Close()
+		}
+
+		switch warningsOutput {
+		case "json", "yaml":
+			out := err
+			if warningsFileHandle != nil {
+				out = warningsFileHandle
+			}
+			if writeErr := warnings.WriteStructured(out, warningsOutput, warningAggregator); writeErr != nil {
+				return writeErr
+			}
+		case "text":
+			if warningsFileHandle != nil {
+				for _, entry := range warningAggregator.Entries() {
+					fmt.Fprintln(warningsFileHandle, entry.Text)
+				}
+			}
+		}
+
+		if warningsAsErrors {
+			count := warningAggregator.Len()
+			switch count {
+			case 0:
+				// no warnings
+			case 1:
+				return fmt.Errorf("%d warning received", count)
+			default:
+				return fmt.Errorf("%d warnings received", count)
+			}
+		}
+		return nil
+	}
+
 	ioStreams := genericclioptions.IOStreams{In: in, Out: out, ErrOut: err}
 
 	loginCmd := login.NewCmdLogin(f, ioStreams)
+	login.WireAuthProviderFlags(loginCmd, ioStreams)
 	secretcmds := secrets.NewCmdSecrets(f, ioStreams)
 
+	authCmd := kubectlwrappers.NewCmdAuth(f, ioStreams)
+	authCmd.AddCommand(login.NewCmdOIDCToken(ioStreams))
+
+	configCmd := kubectlwrappers.NewCmdConfig(f, ioStreams)
+	configCmd.AddCommand(telemetry.NewCmdSetTelemetry(ioStreams))
+
 	groups := ktemplates.CommandGroups{
 		{
 			Message: "Basic Commands:",
@@ -243,13 +334,15 @@ func NewOcCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 			Message: "Advanced Commands:",
 			Commands: []*cobra.Command{
 				admin.NewCommandAdmin(f, ioStreams),
+				archive.NewCmdArchive(f, ioStreams),
+				archive.NewCmdRestore(f, ioStreams),
 				kubectlwrappers.NewCmdReplace(f, ioStreams),
 				kubectlwrappers.NewCmdPatch(f, ioStreams),
 				process.NewCmdProcess(f, ioStreams),
 				extract.NewCmdExtract(f, ioStreams),
 				observe.NewCmdObserve(f, ioStreams),
 				policy.NewCmdPolicy(f, ioStreams),
-				kubectlwrappers.NewCmdAuth(f, ioStreams),
+				authCmd,
 				image.NewCmdImage(f, ioStreams),
 				registry.NewCmd(f, ioStreams),
 				idle.NewCmdIdle(f, ioStreams),
@@ -264,7 +357,8 @@ func NewOcCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 			Message: "Settings Commands:",
 			Commands: []*cobra.Command{
 				logout.NewCmdLogout(f, ioStreams),
-				kubectlwrappers.NewCmdConfig(f, ioStreams),
+				configCmd,
+				telemetry.NewCmdHistory(ioStreams),
 				whoami.NewCmdWhoAmI(f, ioStreams),
 				kubectlwrappers.NewCmdCompletion(ioStreams),
 			},
@@ -281,7 +375,12 @@ func NewOcCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 
 	cmds.AddCommand(newExperimentalCommand(f, ioStreams))
 
-	cmds.AddCommand(kubectlwrappers.NewCmdPlugin(f, ioStreams))
+	pluginCmd := kubectlwrappers.NewCmdPlugin(f, ioStreams)
+	pluginCmd.AddCommand(ocplugin.NewCmdManage(f, ioStreams)...)
+	if listCmd, _, err := pluginCmd.Find([]string{"list"}); err == nil {
+		ocplugin.AddAvailableFlag(listCmd, ioStreams)
+	}
+	cmds.AddCommand(pluginCmd)
 	cmds.AddCommand(version.NewCmdVersion(f, ioStreams))
 	cmds.AddCommand(options.NewCmdOptions(ioStreams))
 
@@ -321,6 +420,7 @@ func changeSharedFlagDefaults(rootCmd *cobra.Command) {
 }
 
 func newExperimentalCommand(f kcmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	disclaimerShown := false
 	experimental := &cobra.Command{
 		Use:   "ex",
 		Short: "Experimental commands under active development",
@@ -329,10 +429,19 @@ func newExperimentalCommand(f kcmdutil.Factory, ioStreams genericclioptions.IOSt
 			c.SetOutput(ioStreams.Out)
 			c.Help()
 		},
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			if !disclaimerShown {
+				fmt.Fprintln(ioStreams.ErrOut, "Warning: commands under 'oc ex' are experimental and may change or be removed without notice.")
+				disclaimerShown = true
+			}
+			return nil
+		},
 	}
 
-	// remove this line, when adding experimental commands
-	experimental.Hidden = true
+	experimental.AddCommand(
+		dockerbuild.NewCmdDockerBuild(ioStreams),
+		importer.NewCmdImporter(f, ioStreams),
+	)
 
 	return experimental
 }
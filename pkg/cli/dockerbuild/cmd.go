@@ -0,0 +1,43 @@
+package dockerbuild
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+var example = ktemplates.Examples(`
+	# Build a Dockerfile in the current directory and push it directly to a registry
+	oc ex dockerbuild . quay.io/myorg/myapp:latest
+
+	# Build against an insecure (http) registry
+	oc ex dockerbuild --insecure-registry . 172.30.1.1:5000/myproject/myapp:latest
+`)
+
+// NewCmdDockerBuild implements `oc ex dockerbuild`, a client-side Dockerfile
+// builder that streams the resulting layer straight to a registry instead
+// of going through a BuildConfig, for clusters that can't reach one (e.g.
+// air-gapped environments).
+func NewCmdDockerBuild(streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "dockerbuild DIR TAG",
+		Short:   "Build a Dockerfile client-side and push it to a registry",
+		Long:    "Build a Dockerfile client-side and push it to a registry without a BuildConfig.\n\nOnly instructions that can be applied without executing anything inside the image are supported: FROM, COPY, ENV, LABEL, CMD, ENTRYPOINT, WORKDIR, USER, and EXPOSE. Dockerfiles that need RUN require a BuildConfig.",
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+	cmd.Flags().StringVar(&o.DockerfilePath, "file", "", "Path to the Dockerfile (defaults to DIR/Dockerfile)")
+	cmd.Flags().BoolVar(&o.Insecure, "insecure-registry", false, "Push to the target registry over plain HTTP")
+	return cmd
+}
@@ -0,0 +1,60 @@
+package dockerbuild
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Instruction is a single parsed Dockerfile line. Only instructions that can
+// be applied without a container runtime are supported - see
+// Options.Run for the list and the error returned for anything else.
+type Instruction struct {
+	Cmd  string
+	Args []string
+}
+
+// ParseDockerfile reads and tokenizes path into a list of Instructions.
+// Continuation lines (trailing "\") and comments are handled; instruction
+// arguments are split on whitespace and otherwise left for the caller to
+// interpret (quoting and the JSON exec form are not parsed).
+func ParseDockerfile(path string) ([]Instruction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Dockerfile: %v", err)
+	}
+	defer f.Close()
+
+	var instructions []Instruction
+	var pending string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			pending += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		line = pending + line
+		pending = ""
+
+		fields := strings.SplitN(line, " ", 2)
+		instr := Instruction{Cmd: strings.ToUpper(fields[0])}
+		if len(fields) > 1 {
+			instr.Args = strings.Fields(strings.TrimSpace(fields[1]))
+		}
+		instructions = append(instructions, instr)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(instructions) == 0 {
+		return nil, fmt.Errorf("Dockerfile contains no instructions")
+	}
+	return instructions, nil
+}
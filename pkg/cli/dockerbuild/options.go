@@ -0,0 +1,287 @@
+package dockerbuild
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// supportedInstructions are the Dockerfile directives dockerbuild can apply
+// without a container runtime. RUN requires executing arbitrary commands
+// inside the image and is rejected, as is every directive not listed here
+// (ADD, ARG, ...); use a BuildConfig for Dockerfiles that need them.
+var supportedInstructions = map[string]bool{
+	"FROM": true, "COPY": true, "ENV": true, "LABEL": true,
+	"CMD": true, "ENTRYPOINT": true, "WORKDIR": true, "USER": true, "EXPOSE": true,
+}
+
+// Options holds the inputs for `oc ex dockerbuild`.
+type Options struct {
+	genericclioptions.IOStreams
+
+	Dir            string
+	Tag            string
+	DockerfilePath string
+	Insecure       bool
+}
+
+// NewOptions returns an Options with IOStreams populated.
+func NewOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{IOStreams: streams}
+}
+
+// Complete fills in Dir, Tag, and the default Dockerfile path from args.
+func (o *Options) Complete(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("exactly two arguments are required: a build context directory and a target tag")
+	}
+	o.Dir = args[0]
+	o.Tag = args[1]
+	if len(o.DockerfilePath) == 0 {
+		o.DockerfilePath = filepath.Join(o.Dir, "Dockerfile")
+	}
+	return nil
+}
+
+// Validate sanity-checks the resolved options.
+func (o *Options) Validate() error {
+	if info, err := os.Stat(o.Dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", o.Dir)
+	}
+	if _, err := os.Stat(o.DockerfilePath); err != nil {
+		return fmt.Errorf("unable to find Dockerfile at %q: %v", o.DockerfilePath, err)
+	}
+	if _, err := parseImageRef(o.Tag); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run parses the Dockerfile, builds a single new layer from its COPY
+// instructions, amends the base image config per its ENV/LABEL/CMD/
+// ENTRYPOINT/WORKDIR/USER instructions, and pushes the result directly to
+// the registry named by Tag - without a local daemon or a BuildConfig.
+func (o *Options) Run() error {
+	instructions, err := ParseDockerfile(o.DockerfilePath)
+	if err != nil {
+		return err
+	}
+
+	for _, instr := range instructions {
+		if !supportedInstructions[instr.Cmd] {
+			return fmt.Errorf("Dockerfile instruction %q is not supported by client-side builds; it requires executing commands inside the image and needs a BuildConfig instead", instr.Cmd)
+		}
+	}
+	if instructions[0].Cmd != "FROM" {
+		return fmt.Errorf("Dockerfile must begin with FROM")
+	}
+
+	baseRef, err := parseImageRef(instructions[0].Args[0])
+	if err != nil {
+		return fmt.Errorf("invalid base image %q: %v", instructions[0].Args[0], err)
+	}
+	targetRef, err := parseImageRef(o.Tag)
+	if err != nil {
+		return err
+	}
+
+	client := newRegistryClient(o.Insecure)
+	baseManifest, cfg, err := client.pullManifest(baseRef.Registry, baseRef.Repository, baseRef.Tag)
+	if err != nil {
+		return err
+	}
+
+	layerData, diffID, err := buildLayer(o.Dir, instructionsOf(instructions, "COPY"))
+	if err != nil {
+		return err
+	}
+
+	applyConfigInstructions(&cfg, instructions)
+	cfg.RootFS.DiffIDs = append(cfg.RootFS.DiffIDs, diffID)
+	cfg.History = append(cfg.History, history{CreatedBy: "oc ex dockerbuild"})
+
+	configData, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Pushing layer to %s...\n", targetRef)
+	layerDesc, err := client.pushBlob(targetRef.Registry, targetRef.Repository, layerData)
+	if err != nil {
+		return err
+	}
+	layerDesc.MediaType = mediaTypeLayer
+
+	configDesc, err := client.pushBlob(targetRef.Registry, targetRef.Repository, configData)
+	if err != nil {
+		return err
+	}
+	configDesc.MediaType = mediaTypeConfig
+
+	newManifest := manifest{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeManifest,
+		Config:        configDesc,
+		Layers:        append(baseManifest.Layers, layerDesc),
+	}
+
+	if err := client.pushManifest(targetRef.Registry, targetRef.Repository, targetRef.Tag, newManifest); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Pushed %s\n", targetRef)
+	return nil
+}
+
+func instructionsOf(instructions []Instruction, cmd string) []Instruction {
+	var out []Instruction
+	for _, instr := range instructions {
+		if instr.Cmd == cmd {
+			out = append(out, instr)
+		}
+	}
+	return out
+}
+
+// buildLayer tars up every COPY instruction's sources, relative to dir,
+// gzips the result, and returns the compressed blob along with the
+// uncompressed layer's sha256 diffID.
+func buildLayer(dir string, copies []Instruction) ([]byte, string, error) {
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+
+	for _, instr := range copies {
+		if len(instr.Args) < 2 {
+			return nil, "", fmt.Errorf("COPY requires a source and destination")
+		}
+		sources, dest := instr.Args[:len(instr.Args)-1], instr.Args[len(instr.Args)-1]
+		for _, src := range sources {
+			if err := addToTar(tw, dir, src, dest); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	diffSum := sha256.Sum256(tarBuf.Bytes())
+	diffID := "sha256:" + hex.EncodeToString(diffSum[:])
+
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	if _, err := gw.Write(tarBuf.Bytes()); err != nil {
+		return nil, "", err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return gzBuf.Bytes(), diffID, nil
+}
+
+func addToTar(tw *tar.Writer, baseDir, src, dest string) error {
+	srcPath := filepath.Join(baseDir, src)
+	return filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcPath, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(filepath.Join(dest, rel))
+
+		if info.IsDir() {
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+func applyConfigInstructions(cfg *imageConfig, instructions []Instruction) {
+	if cfg.Config.Labels == nil {
+		cfg.Config.Labels = map[string]string{}
+	}
+	for _, instr := range instructions {
+		switch instr.Cmd {
+		case "ENV":
+			for _, kv := range parseAssignments(instr.Args) {
+				cfg.Config.Env = append(cfg.Config.Env, kv[0]+"="+kv[1])
+			}
+		case "LABEL":
+			for _, kv := range parseAssignments(instr.Args) {
+				cfg.Config.Labels[kv[0]] = kv[1]
+			}
+		case "CMD":
+			cfg.Config.Cmd = instr.Args
+		case "ENTRYPOINT":
+			cfg.Config.Entrypoint = instr.Args
+		case "WORKDIR":
+			if len(instr.Args) > 0 {
+				cfg.Config.WorkingDir = instr.Args[0]
+			}
+		case "USER":
+			if len(instr.Args) > 0 {
+				cfg.Config.User = instr.Args[0]
+			}
+		case "EXPOSE":
+			if cfg.Config.ExposedPorts == nil {
+				cfg.Config.ExposedPorts = map[string]struct{}{}
+			}
+			for _, port := range instr.Args {
+				if !strings.Contains(port, "/") {
+					port += "/tcp"
+				}
+				cfg.Config.ExposedPorts[port] = struct{}{}
+			}
+		}
+	}
+}
+
+// parseAssignments turns ENV/LABEL arguments into key/value pairs, handling
+// both the modern "KEY1=VAL1 KEY2=VAL2" form (each token is independent) and
+// the legacy "KEY VALUE" form (a bare token with no "=" consumes the next
+// token as its value). A trailing bare token with nothing left to consume
+// is dropped.
+func parseAssignments(args []string) [][2]string {
+	var out [][2]string
+	for i := 0; i < len(args); i++ {
+		if key, value, ok := strings.Cut(args[i], "="); ok {
+			out = append(out, [2]string{key, value})
+			continue
+		}
+		if i+1 < len(args) {
+			out = append(out, [2]string{args[i], args[i+1]})
+			i++
+		}
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package dockerbuild
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAssignments(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want [][2]string
+	}{
+		{
+			name: "single KEY=VALUE token",
+			args: []string{"FOO=bar"},
+			want: [][2]string{{"FOO", "bar"}},
+		},
+		{
+			name: "multiple KEY=VALUE tokens",
+			args: []string{"FOO=bar", "BAZ=qux"},
+			want: [][2]string{{"FOO", "bar"}, {"BAZ", "qux"}},
+		},
+		{
+			name: "legacy KEY VALUE pair",
+			args: []string{"FOO", "bar"},
+			want: [][2]string{{"FOO", "bar"}},
+		},
+		{
+			name: "value containing an equals sign",
+			args: []string{"FOO=bar=baz"},
+			want: [][2]string{{"FOO", "bar=baz"}},
+		},
+		{
+			name: "trailing bare token with nothing to consume is dropped",
+			args: []string{"FOO=bar", "DANGLING"},
+			want: [][2]string{{"FOO", "bar"}},
+		},
+		{
+			name: "empty args",
+			args: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseAssignments(tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAssignments(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyConfigInstructionsEnvAndLabel(t *testing.T) {
+	cfg := &imageConfig{}
+	instructions := []Instruction{
+		{Cmd: "ENV", Args: []string{"FOO=bar", "BAZ=qux"}},
+		{Cmd: "ENV", Args: []string{"LEGACY", "value"}},
+		{Cmd: "LABEL", Args: []string{"maintainer=oc"}},
+	}
+
+	applyConfigInstructions(cfg, instructions)
+
+	wantEnv := []string{"FOO=bar", "BAZ=qux", "LEGACY=value"}
+	if !reflect.DeepEqual(cfg.Config.Env, wantEnv) {
+		t.Errorf("Config.Env = %v, want %v", cfg.Config.Env, wantEnv)
+	}
+	if got := cfg.Config.Labels["maintainer"]; got != "oc" {
+		t.Errorf("Config.Labels[maintainer] = %q, want %q", got, "oc")
+	}
+}
+
+func TestApplyConfigInstructionsExpose(t *testing.T) {
+	cfg := &imageConfig{}
+	instructions := []Instruction{
+		{Cmd: "EXPOSE", Args: []string{"8080"}},
+		{Cmd: "EXPOSE", Args: []string{"53/udp"}},
+	}
+
+	applyConfigInstructions(cfg, instructions)
+
+	want := map[string]struct{}{"8080/tcp": {}, "53/udp": {}}
+	if !reflect.DeepEqual(cfg.Config.ExposedPorts, want) {
+		t.Errorf("Config.ExposedPorts = %v, want %v", cfg.Config.ExposedPorts, want)
+	}
+}
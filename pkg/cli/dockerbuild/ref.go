@@ -0,0 +1,39 @@
+package dockerbuild
+
+import (
+	"fmt"
+	"strings"
+)
+
+// imageRef is a parsed registry/repository:tag reference. It intentionally
+// doesn't support digests; dockerbuild only ever produces and consumes
+// tags.
+type imageRef struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+func (r imageRef) String() string {
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Tag)
+}
+
+// parseImageRef parses a "registry/repository:tag" reference. A reference
+// with no registry component (a single path segment, or one without a dot
+// or port in its first segment) is rejected - dockerbuild always pushes to
+// an explicit registry.
+func parseImageRef(ref string) (imageRef, error) {
+	tag := "latest"
+	name := ref
+	if idx := strings.LastIndex(ref, ":"); idx > strings.LastIndex(ref, "/") {
+		name = ref[:idx]
+		tag = ref[idx+1:]
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) != 2 || !strings.ContainsAny(parts[0], ".:") {
+		return imageRef{}, fmt.Errorf("%q must include a registry host, e.g. registry.example.com/%s", ref, ref)
+	}
+
+	return imageRef{Registry: parts[0], Repository: parts[1], Tag: tag}, nil
+}
@@ -0,0 +1,210 @@
+package dockerbuild
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// registryClient is a minimal docker v2 distribution client supporting just
+// enough of the protocol (manifest/blob GET, blob POST/PUT, manifest PUT,
+// and bearer-token re-auth) to pull a base image and push a derived one.
+//
+// This intentionally doesn't reuse pkg/cli/image's registry transports:
+// those are built around copying existing blobs between registries (mirror,
+// extract), not assembling a brand-new config and layer from local build
+// output and pushing just those two blobs plus a manifest. The two clients
+// would share little beyond the HTTP plumbing here, so dockerbuild keeps its
+// own narrow client rather than bending a copy-oriented API to a build-time
+// push. If a third oc command needs the same narrow push-only path, this
+// type is small enough to lift out into a shared package at that point.
+type registryClient struct {
+	httpClient *http.Client
+	insecure   bool
+}
+
+func newRegistryClient(insecure bool) *registryClient {
+	return &registryClient{httpClient: &http.Client{}, insecure: insecure}
+}
+
+func (c *registryClient) scheme() string {
+	if c.insecure {
+		return "http"
+	}
+	return "https"
+}
+
+func (c *registryClient) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		token, tokenErr := c.authenticate(resp)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("unable to rewind request body for retry: %v", err)
+			}
+			req.Body = body
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return c.httpClient.Do(req)
+	}
+	return resp, nil
+}
+
+// authenticate implements the anonymous bearer-token flow described by the
+// WWW-Authenticate header of a 401 response. The token request carries no
+// credentials, so registries that require real credentials are not
+// supported.
+func (c *registryClient) authenticate(resp *http.Response) (string, error) {
+	challenge := resp.Header.Get("Www-Authenticate")
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("registry requires unsupported authentication: %s", challenge)
+	}
+
+	params := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	realm, ok := params["realm"]
+	if !ok {
+		return "", fmt.Errorf("registry auth challenge missing realm: %s", challenge)
+	}
+
+	url := realm + "?service=" + params["service"] + "&scope=" + params["scope"]
+	tokenResp, err := c.httpClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer tokenResp.Body.Close()
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(tokenResp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("unable to parse registry token response: %v", err)
+	}
+	if len(body.Token) > 0 {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+func (c *registryClient) pullManifest(registry, repository, reference string) (manifest, imageConfig, error) {
+	var m manifest
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s://%s/v2/%s/manifests/%s", c.scheme(), registry, repository, reference), nil)
+	req.Header.Set("Accept", mediaTypeManifest)
+	resp, err := c.do(req)
+	if err != nil {
+		return m, imageConfig{}, fmt.Errorf("unable to fetch base image manifest: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return m, imageConfig{}, fmt.Errorf("unable to fetch base image manifest: unexpected status %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return m, imageConfig{}, fmt.Errorf("unable to parse base image manifest: %v", err)
+	}
+
+	cfg, err := c.pullConfig(registry, repository, m.Config.Digest)
+	if err != nil {
+		return m, imageConfig{}, err
+	}
+	return m, cfg, nil
+}
+
+func (c *registryClient) pullConfig(registry, repository, digest string) (imageConfig, error) {
+	var cfg imageConfig
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s://%s/v2/%s/blobs/%s", c.scheme(), registry, repository, digest), nil)
+	resp, err := c.do(req)
+	if err != nil {
+		return cfg, fmt.Errorf("unable to fetch base image config: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return cfg, fmt.Errorf("unable to fetch base image config: unexpected status %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("unable to parse base image config: %v", err)
+	}
+	return cfg, nil
+}
+
+// pushBlob uploads data as a single-chunk blob upload and returns its
+// digest and size.
+func (c *registryClient) pushBlob(registry, repository string, data []byte) (descriptor, error) {
+	sum := sha256.Sum256(data)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	startReq, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s://%s/v2/%s/blobs/uploads/", c.scheme(), registry, repository), nil)
+	startResp, err := c.do(startReq)
+	if err != nil {
+		return descriptor{}, fmt.Errorf("unable to start blob upload: %v", err)
+	}
+	startResp.Body.Close()
+	if startResp.StatusCode != http.StatusAccepted {
+		return descriptor{}, fmt.Errorf("unable to start blob upload: unexpected status %s", startResp.Status)
+	}
+
+	location := startResp.Header.Get("Location")
+	// The Location header may be relative to the upload request's URL.
+	if locationURL, err := startReq.URL.Parse(location); err == nil {
+		location = locationURL.String()
+	}
+	sep := "?"
+	if strings.Contains(location, "?") {
+		sep = "&"
+	}
+	putReq, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s%sdigest=%s", location, sep, digest), bytes.NewReader(data))
+	putReq.Header.Set("Content-Type", "application/octet-stream")
+	putReq.ContentLength = int64(len(data))
+	putResp, err := c.do(putReq)
+	if err != nil {
+		return descriptor{}, fmt.Errorf("unable to upload blob: %v", err)
+	}
+	defer putResp.Body.Close()
+	if putResp.StatusCode != http.StatusCreated {
+		return descriptor{}, fmt.Errorf("unable to upload blob: unexpected status %s", putResp.Status)
+	}
+
+	return descriptor{Size: int64(len(data)), Digest: digest}, nil
+}
+
+func (c *registryClient) pushManifest(registry, repository, tag string, m manifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/v2/%s/manifests/%s", c.scheme(), registry, repository, tag), bytes.NewReader(data))
+	req.Header.Set("Content-Type", mediaTypeManifest)
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("unable to push manifest: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unable to push manifest: unexpected status %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package dockerbuild
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRegistryClientPushBlobWithBearerAuth exercises the 401-then-retry
+// bearer-token flow: pushBlob's first request is rejected, it fetches a
+// token from the realm advertised in WWW-Authenticate, and retries with it.
+func TestRegistryClientPushBlobWithBearerAuth(t *testing.T) {
+	var tokenServer *httptest.Server
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repo:repo/name:push"`, tokenServer.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/blobs/uploads/"):
+			w.Header().Set("Location", r.URL.Path+"upload-1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && strings.Contains(r.URL.Path, "upload-1"):
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	tokenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"token":"test-token"}`)
+	}))
+	defer tokenServer.Close()
+
+	c := newRegistryClient(true)
+	registryHost := strings.TrimPrefix(registry.URL, "http://")
+
+	desc, err := c.pushBlob(registryHost, "repo/name", []byte("hello"))
+	if err != nil {
+		t.Fatalf("pushBlob: %v", err)
+	}
+	if desc.Size != int64(len("hello")) {
+		t.Errorf("Size = %d, want %d", desc.Size, len("hello"))
+	}
+	if !strings.HasPrefix(desc.Digest, "sha256:") {
+		t.Errorf("Digest = %q, want sha256: prefix", desc.Digest)
+	}
+}
+
+func TestRegistryClientPullManifest(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/manifests/latest"):
+			fmt.Fprintf(w, `{"schemaVersion":2,"mediaType":%q,"config":{"digest":"sha256:deadbeef","size":2}}`, mediaTypeManifest)
+		case strings.Contains(r.URL.Path, "/blobs/sha256:deadbeef"):
+			fmt.Fprint(w, `{"architecture":"amd64","os":"linux","config":{}}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	c := newRegistryClient(true)
+	registryHost := strings.TrimPrefix(registry.URL, "http://")
+
+	m, cfg, err := c.pullManifest(registryHost, "repo/name", "latest")
+	if err != nil {
+		t.Fatalf("pullManifest: %v", err)
+	}
+	if m.Config.Digest != "sha256:deadbeef" {
+		t.Errorf("Config.Digest = %q, want sha256:deadbeef", m.Config.Digest)
+	}
+	if cfg.Architecture != "amd64" {
+		t.Errorf("Architecture = %q, want amd64", cfg.Architecture)
+	}
+}
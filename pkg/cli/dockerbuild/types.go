@@ -0,0 +1,59 @@
+package dockerbuild
+
+// The types below are the minimal subset of the OCI/Docker v2 image
+// manifest and config schemas this package needs to read a base image and
+// write a derived one; they deliberately don't attempt to be a complete
+// implementation of either spec.
+
+const (
+	mediaTypeManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeConfig   = "application/vnd.docker.container.image.v1+json"
+	mediaTypeLayer    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// manifest is a docker v2 schema2 image manifest.
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+// descriptor references a content-addressed blob.
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+	Digest    string `json:"digest"`
+}
+
+// imageConfig is the subset of the container image config JSON that
+// dockerbuild reads from the base image and can be amended from Dockerfile
+// instructions.
+type imageConfig struct {
+	Architecture string       `json:"architecture"`
+	OS           string       `json:"os"`
+	Config       configDetail `json:"config"`
+	RootFS       rootFS       `json:"rootfs"`
+	History      []history    `json:"history"`
+}
+
+type configDetail struct {
+	Env          []string            `json:"Env,omitempty"`
+	Cmd          []string            `json:"Cmd,omitempty"`
+	Entrypoint   []string            `json:"Entrypoint,omitempty"`
+	WorkingDir   string              `json:"WorkingDir,omitempty"`
+	User         string              `json:"User,omitempty"`
+	Labels       map[string]string   `json:"Labels,omitempty"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+}
+
+type rootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids"`
+}
+
+type history struct {
+	Created    string `json:"created,omitempty"`
+	CreatedBy  string `json:"created_by,omitempty"`
+	EmptyLayer bool   `json:"empty_layer,omitempty"`
+}
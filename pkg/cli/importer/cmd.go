@@ -0,0 +1,36 @@
+package importer
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+var example = ktemplates.Examples(`
+	# Import every recognized file in a directory into the current project
+	oc ex importer ./manifests
+`)
+
+// NewCmdImporter implements `oc ex importer`, a bulk importer that turns a
+// directory of docker-compose files, Helm values files, and plain manifests
+// into objects in the current project.
+func NewCmdImporter(f kcmdutil.Factory, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(streams)
+
+	return &cobra.Command{
+		Use:     "importer DIR",
+		Short:   "Import a directory of manifests, compose files, and Helm values into the current project",
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(f, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// composeSource reads a docker-compose file and produces one Deployment and
+// (if the service publishes ports) one Service per compose service. Fields
+// outside image/ports/environment/command (volumes, networks, depends_on,
+// ...) are intentionally dropped - it's a starting point for a project, not
+// a compose-compatibility layer.
+type composeSource struct{}
+
+type composeFile struct {
+	Services map[string]composeService `json:"services"`
+}
+
+type composeService struct {
+	Image       string            `json:"image"`
+	Ports       []string          `json:"ports"`
+	Environment map[string]string `json:"environment"`
+	Command     []string          `json:"command"`
+}
+
+func (composeSource) Name() string { return "docker-compose" }
+
+func (composeSource) Matches(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return strings.HasPrefix(base, "docker-compose.") || base == "compose.yaml" || base == "compose.yml"
+}
+
+func (composeSource) Convert(path string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if len(file.Services) == 0 {
+		return nil, fmt.Errorf("no services defined")
+	}
+
+	var objects []runtime.Object
+	for name, svc := range file.Services {
+		if len(svc.Image) == 0 {
+			return nil, fmt.Errorf("service %q has no image", name)
+		}
+
+		objects = append(objects, deploymentFor(name, svc))
+		if svc, ports := svc, servicePorts(svc.Ports); len(ports) > 0 {
+			objects = append(objects, serviceFor(name, ports))
+		}
+	}
+	return objects, nil
+}
+
+func deploymentFor(name string, svc composeService) *appsv1.Deployment {
+	labels := map[string]string{"app": name}
+
+	var env []corev1.EnvVar
+	for k, v := range svc.Environment {
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+
+	container := corev1.Container{
+		Name:    name,
+		Image:   svc.Image,
+		Env:     env,
+		Command: svc.Command,
+	}
+	for _, p := range servicePorts(svc.Ports) {
+		container.Ports = append(container.Ports, corev1.ContainerPort{ContainerPort: p.containerPort})
+	}
+
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{container}},
+			},
+		},
+	}
+}
+
+func serviceFor(name string, ports []composePort) *corev1.Service {
+	labels := map[string]string{"app": name}
+
+	var svcPorts []corev1.ServicePort
+	for _, p := range ports {
+		svcPorts = append(svcPorts, corev1.ServicePort{
+			Port:       p.servicePort,
+			TargetPort: intstr.FromInt(int(p.containerPort)),
+		})
+	}
+
+	return &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       corev1.ServiceSpec{Selector: labels, Ports: svcPorts},
+	}
+}
+
+type composePort struct {
+	servicePort   int32
+	containerPort int32
+}
+
+// servicePorts parses compose's "HOST:CONTAINER" or "PORT" port syntax.
+// Entries that don't parse as plain numbers are skipped rather than
+// failing the whole import.
+func servicePorts(raw []string) []composePort {
+	var ports []composePort
+	for _, p := range raw {
+		parts := strings.SplitN(p, ":", 2)
+		var host, container string
+		if len(parts) == 2 {
+			host, container = parts[0], parts[1]
+		} else {
+			host, container = parts[0], parts[0]
+		}
+
+		hostPort, err1 := strconv.Atoi(host)
+		containerPort, err2 := strconv.Atoi(container)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		ports = append(ports, composePort{servicePort: int32(hostPort), containerPort: int32(containerPort)})
+	}
+	return ports
+}
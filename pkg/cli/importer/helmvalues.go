@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// helmValuesSource handles a bare values.yaml with no accompanying chart -
+// there are no templates to render, so it applies a narrow heuristic: an
+// "image.repository" key (plus an optional "image.tag") and an optional
+// "replicaCount" are turned into a single Deployment named after the
+// directory the file lives in; a scalar "image" value is not accepted.
+// Anything else in the file is ignored. This
+// covers the common case of a values override with no chart of its own
+// checked into the same project; real charts should be rendered with `helm
+// template` and imported as plain manifests instead.
+type helmValuesSource struct{}
+
+type helmValues struct {
+	Image struct {
+		Repository string `json:"repository"`
+		Tag        string `json:"tag"`
+	} `json:"image"`
+	ReplicaCount *int32 `json:"replicaCount"`
+}
+
+func (helmValuesSource) Name() string { return "helm-values" }
+
+func (helmValuesSource) Matches(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return base == "values.yaml" || base == "values.yml"
+}
+
+func (helmValuesSource) Convert(path string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values helmValues
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if len(values.Image.Repository) == 0 {
+		return nil, fmt.Errorf("values file has no image.repository; nothing to import")
+	}
+
+	image := values.Image.Repository
+	if len(values.Image.Tag) > 0 {
+		image = image + ":" + values.Image.Tag
+	}
+
+	replicas := int32(1)
+	if values.ReplicaCount != nil {
+		replicas = *values.ReplicaCount
+	}
+
+	name := filepath.Base(filepath.Dir(path))
+	if name == "." || name == "/" || len(name) == 0 {
+		name = "app"
+	}
+	labels := map[string]string{"app": name}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: name, Image: image}},
+				},
+			},
+		},
+	}
+	return []runtime.Object{deployment}, nil
+}
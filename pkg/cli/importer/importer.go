@@ -0,0 +1,73 @@
+// Package importer implements `oc ex importer`, a bulk importer that reads
+// a directory of source files in a handful of common formats and
+// materializes the equivalent Kubernetes/OpenShift objects into the current
+// project.
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Source converts files it recognizes in a directory into a list of objects
+// to apply. Each supported input format (docker-compose, Helm values, plain
+// manifests) implements Source.
+type Source interface {
+	// Name identifies the source in diagnostic output.
+	Name() string
+	// Matches returns whether path looks like this source's format.
+	Matches(path string) bool
+	// Convert reads path and returns the objects it describes.
+	Convert(path string) ([]runtime.Object, error)
+}
+
+// Sources are tried, in order, against every file found by Walk. The first
+// Source whose Matches returns true for a given file handles it.
+var Sources = []Source{
+	composeSource{},
+	helmValuesSource{},
+	manifestSource{},
+}
+
+// Walk finds every file directly under dir (not recursively - nested
+// directories are typically Helm chart templates or other material that
+// isn't itself a source document) and converts it with the first matching
+// Source.
+func Walk(dir string) ([]runtime.Object, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %q: %v", dir, err)
+	}
+
+	var objects []runtime.Object
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		source := matchSource(path)
+		if source == nil {
+			continue
+		}
+
+		converted, err := source.Convert(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import %q as %s: %v", path, source.Name(), err)
+		}
+		objects = append(objects, converted...)
+	}
+	return objects, nil
+}
+
+func matchSource(path string) Source {
+	for _, s := range Sources {
+		if s.Matches(path) {
+			return s
+		}
+	}
+	return nil
+}
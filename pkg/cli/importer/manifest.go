@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestSource handles plain Kubernetes/OpenShift manifests: any YAML or
+// JSON document that already has apiVersion and kind set is applied as-is.
+// It's tried last since it's the most permissive match.
+type manifestSource struct{}
+
+func (manifestSource) Name() string { return "manifest" }
+
+func (manifestSource) Matches(path string) bool {
+	ext := strings.ToLower(filepathExt(path))
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+func (manifestSource) Convert(path string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []runtime.Object
+	for _, doc := range splitYAMLDocuments(data) {
+		if len(strings.TrimSpace(string(doc))) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &u.Object); err != nil {
+			return nil, err
+		}
+		if len(u.GetAPIVersion()) == 0 || len(u.GetKind()) == 0 {
+			continue
+		}
+		objects = append(objects, u)
+	}
+	return objects, nil
+}
+
+func filepathExt(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[i:]
+	}
+	return ""
+}
+
+func splitYAMLDocuments(data []byte) [][]byte {
+	return bytes.Split(data, []byte("\n---\n"))
+}
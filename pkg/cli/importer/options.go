@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// Options holds the inputs for `oc ex importer`.
+type Options struct {
+	genericclioptions.IOStreams
+
+	Dir       string
+	Namespace string
+
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+}
+
+// NewOptions returns an Options with IOStreams populated.
+func NewOptions(streams genericclioptions.IOStreams) *Options {
+	return &Options{IOStreams: streams}
+}
+
+// Complete fills in Dir from args and resolves the client and namespace
+// from f, the same Factory threaded through the rest of NewOcCommand.
+func (o *Options) Complete(f kcmdutil.Factory, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one argument is required: a directory to import")
+	}
+	o.Dir = args[0]
+
+	namespace, _, err := f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+
+	o.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return err
+	}
+	o.mapper, err = f.ToRESTMapper()
+	return err
+}
+
+// Validate sanity-checks the resolved options.
+func (o *Options) Validate() error {
+	info, err := os.Stat(o.Dir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", o.Dir)
+	}
+	return nil
+}
+
+// Run converts every recognized file under Dir and creates the resulting
+// objects in the current project.
+func (o *Options) Run() error {
+	objects, err := Walk(o.Dir)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		fmt.Fprintln(o.Out, "No importable files found")
+		return nil
+	}
+
+	for _, obj := range objects {
+		if err := o.create(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) create(obj runtime.Object) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	mapping, err := o.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("unable to resolve %s %q: %v", gvk.Kind, accessor.GetName(), err)
+	}
+
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	u.SetNamespace(o.Namespace)
+
+	var resourceClient dynamic.ResourceInterface
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceClient = o.dynamicClient.Resource(mapping.Resource).Namespace(o.Namespace)
+	} else {
+		resourceClient = o.dynamicClient.Resource(mapping.Resource)
+	}
+
+	if _, err := resourceClient.Create(cmdContext(), u, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("unable to create %s %q: %v", gvk.Kind, accessor.GetName(), err)
+	}
+
+	fmt.Fprintf(o.Out, "%s/%s created\n", gvk.Kind, accessor.GetName())
+	return nil
+}
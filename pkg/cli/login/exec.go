@@ -0,0 +1,84 @@
+// Package login adds exec-based credential providers on top of the
+// existing `oc login` command: shelling out to an arbitrary command (e.g.
+// `aws eks get-token`), and an OIDC device-code flow that refreshes through
+// a new `oc auth oidc-token` exec plugin.
+package login
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// execAPIVersion is the only client.authentication.k8s.io version oc
+// understands when writing an exec credential provider.
+const execAPIVersion = "client.authentication.k8s.io/v1"
+
+// ExecFlags holds the --exec-* flags that configure an arbitrary exec
+// credential plugin for `oc login --auth-provider-exec`.
+type ExecFlags struct {
+	Enabled            bool
+	Command            string
+	Args               []string
+	Env                []string
+	InteractiveMode    string
+	ProvideClusterInfo bool
+}
+
+// AddExecFlags registers the --exec-* flags on cmd and returns the struct
+// they're bound to.
+func AddExecFlags(cmd *cobra.Command) *ExecFlags {
+	f := &ExecFlags{InteractiveMode: "IfAvailable"}
+	cmd.Flags().BoolVar(&f.Enabled, "auth-provider-exec", false, "Configure the current context to authenticate by running an external command instead of storing a token")
+	cmd.Flags().StringVar(&f.Command, "exec-command", "", "Command to run for --auth-provider-exec, e.g. aws")
+	cmd.Flags().StringArrayVar(&f.Args, "exec-arg", nil, "Argument to pass to --exec-command; may be repeated in order")
+	cmd.Flags().StringArrayVar(&f.Env, "exec-env", nil, "NAME=VALUE environment variable to set for --exec-command; may be repeated")
+	cmd.Flags().StringVar(&f.InteractiveMode, "exec-interactive-mode", f.InteractiveMode, "One of Never, IfAvailable, Always - passed through to the exec plugin")
+	cmd.Flags().BoolVar(&f.ProvideClusterInfo, "exec-provide-cluster-info", false, "Pass cluster information to --exec-command on stdin")
+	return f
+}
+
+// Validate checks that the exec flags are internally consistent.
+func (f *ExecFlags) Validate() error {
+	if !f.Enabled {
+		return nil
+	}
+	if len(f.Command) == 0 {
+		return fmt.Errorf("--exec-command is required when --auth-provider-exec is set")
+	}
+	switch f.InteractiveMode {
+	case "Never", "IfAvailable", "Always":
+	default:
+		return fmt.Errorf("--exec-interactive-mode must be one of Never, IfAvailable, Always")
+	}
+	return nil
+}
+
+// BuildExecConfig turns the resolved flags into the kubeconfig
+// users[].exec stanza oc login writes for the current context.
+func (f *ExecFlags) BuildExecConfig() *clientcmdapi.ExecConfig {
+	var env []clientcmdapi.ExecEnvVar
+	for _, kv := range f.Env {
+		name, value := splitEnvVar(kv)
+		env = append(env, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+	}
+
+	return &clientcmdapi.ExecConfig{
+		APIVersion:         execAPIVersion,
+		Command:            f.Command,
+		Args:               f.Args,
+		Env:                env,
+		InteractiveMode:    clientcmdapi.ExecInteractiveMode(f.InteractiveMode),
+		ProvideClusterInfo: f.ProvideClusterInfo,
+	}
+}
+
+func splitEnvVar(kv string) (string, string) {
+	for i := 0; i < len(kv); i++ {
+		if kv[i] == '=' {
+			return kv[:i], kv[i+1:]
+		}
+	}
+	return kv, ""
+}
@@ -0,0 +1,94 @@
+package login
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// establishExecContext loads the default kubeconfig and creates or updates
+// a cluster/context/user triple for server, pointing the user at execConfig,
+// then makes it current. Unlike writing onto an existing current context,
+// this works on a first-ever login: --auth-provider-exec and
+// --oidc-issuer-url don't go through the normal username/password or token
+// prompt, so there's no earlier step that would have created a context to
+// mutate.
+func establishExecContext(cmd *cobra.Command, server, authInfoName string, execConfig *clientcmdapi.ExecConfig) error {
+	if len(server) == 0 {
+		return fmt.Errorf("a server URL is required, e.g. oc login https://api.example.com:6443 ...")
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	config, err := rules.Load()
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig: %v", err)
+	}
+	if config.Clusters == nil {
+		config.Clusters = map[string]*clientcmdapi.Cluster{}
+	}
+	if config.AuthInfos == nil {
+		config.AuthInfos = map[string]*clientcmdapi.AuthInfo{}
+	}
+	if config.Contexts == nil {
+		config.Contexts = map[string]*clientcmdapi.Context{}
+	}
+
+	certificateAuthority, _ := cmd.Flags().GetString("certificate-authority")
+	insecureSkipTLSVerify, _ := cmd.Flags().GetBool("insecure-skip-tls-verify")
+
+	clusterName := clusterNameForServer(server)
+	cluster, ok := config.Clusters[clusterName]
+	if !ok {
+		cluster = clientcmdapi.NewCluster()
+		config.Clusters[clusterName] = cluster
+	}
+	cluster.Server = server
+	cluster.InsecureSkipTLSVerify = insecureSkipTLSVerify
+	if len(certificateAuthority) > 0 {
+		cluster.CertificateAuthority = certificateAuthority
+		cluster.CertificateAuthorityData = nil
+	}
+
+	authInfo, ok := config.AuthInfos[authInfoName]
+	if !ok {
+		authInfo = clientcmdapi.NewAuthInfo()
+		config.AuthInfos[authInfoName] = authInfo
+	}
+	authInfo.Exec = execConfig
+	authInfo.Token = ""
+	authInfo.ClientCertificateData = nil
+	authInfo.ClientKeyData = nil
+
+	contextName := clusterName + "/" + authInfoName
+	context, ok := config.Contexts[contextName]
+	if !ok {
+		context = clientcmdapi.NewContext()
+		config.Contexts[contextName] = context
+	}
+	context.Cluster = clusterName
+	context.AuthInfo = authInfoName
+
+	config.CurrentContext = contextName
+
+	return clientcmd.ModifyConfig(rules, *config, true)
+}
+
+// clusterNameForServer derives a kubeconfig cluster nickname from a server
+// URL the same way `oc login` itself does, e.g.
+// "https://api.example.com:6443" -> "api-example-com:6443".
+func clusterNameForServer(server string) string {
+	host := server
+	if u, err := url.Parse(server); err == nil && len(u.Host) > 0 {
+		host = u.Host
+	}
+	name := strings.ReplaceAll(host, ".", "-")
+	if !strings.HasPrefix(name, "api-") {
+		name = "api-" + name
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package login
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestEstablishExecContextFirstLogin(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	t.Setenv("KUBECONFIG", kubeconfigPath)
+
+	cmd := &cobra.Command{Use: "login"}
+	cmd.Flags().String("certificate-authority", "", "")
+	cmd.Flags().Bool("insecure-skip-tls-verify", false, "")
+
+	execConfig := &clientcmdapi.ExecConfig{
+		APIVersion: execAPIVersion,
+		Command:    "aws",
+	}
+
+	if err := establishExecContext(cmd, "https://api.example.com:6443", "aws", execConfig); err != nil {
+		t.Fatalf("establishExecContext on an empty kubeconfig: %v", err)
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	const wantCluster = "api-example-com:6443"
+	const wantContext = wantCluster + "/aws"
+
+	if config.CurrentContext != wantContext {
+		t.Errorf("CurrentContext = %q, want %q", config.CurrentContext, wantContext)
+	}
+	cluster, ok := config.Clusters[wantCluster]
+	if !ok {
+		t.Fatalf("cluster %q was not created", wantCluster)
+	}
+	if cluster.Server != "https://api.example.com:6443" {
+		t.Errorf("cluster.Server = %q, want %q", cluster.Server, "https://api.example.com:6443")
+	}
+	authInfo, ok := config.AuthInfos["aws"]
+	if !ok {
+		t.Fatalf("user %q was not created", "aws")
+	}
+	if authInfo.Exec == nil || authInfo.Exec.Command != "aws" {
+		t.Errorf("authInfo.Exec = %+v, want Command=aws", authInfo.Exec)
+	}
+	context, ok := config.Contexts[wantContext]
+	if !ok {
+		t.Fatalf("context %q was not created", wantContext)
+	}
+	if context.Cluster != wantCluster || context.AuthInfo != "aws" {
+		t.Errorf("context = %+v, want Cluster=%q AuthInfo=aws", context, wantCluster)
+	}
+}
+
+func TestEstablishExecContextRequiresServer(t *testing.T) {
+	cmd := &cobra.Command{Use: "login"}
+	if err := establishExecContext(cmd, "", "aws", &clientcmdapi.ExecConfig{}); err == nil {
+		t.Fatal("expected an error when no server is given")
+	}
+}
+
+func TestClusterNameForServer(t *testing.T) {
+	tests := []struct {
+		server string
+		want   string
+	}{
+		{"https://api.example.com:6443", "api-example-com:6443"},
+		{"https://10.0.0.1:6443", "api-10-0-0-1:6443"},
+	}
+	for _, tt := range tests {
+		if got := clusterNameForServer(tt.server); got != tt.want {
+			t.Errorf("clusterNameForServer(%q) = %q, want %q", tt.server, got, tt.want)
+		}
+	}
+}
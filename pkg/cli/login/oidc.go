@@ -0,0 +1,211 @@
+package login
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// keyringService is the go-keyring service name refresh tokens are stored
+// under; the account name is issuer+clientID so multiple IdPs can coexist.
+const keyringService = "oc-oidc-token"
+
+// OIDCFlags holds the --oidc-* flags for `oc login`'s PKCE device-code
+// flow.
+type OIDCFlags struct {
+	IssuerURL string
+	ClientID  string
+}
+
+// AddOIDCFlags registers the --oidc-* flags on cmd.
+func AddOIDCFlags(cmd *cobra.Command) *OIDCFlags {
+	f := &OIDCFlags{}
+	cmd.Flags().StringVar(&f.IssuerURL, "oidc-issuer-url", "", "Issuer URL of an OpenID Connect provider to authenticate against")
+	cmd.Flags().StringVar(&f.ClientID, "oidc-client-id", "", "OAuth2 client ID registered with --oidc-issuer-url")
+	return f
+}
+
+// Enabled reports whether the user asked for the OIDC flow.
+func (f *OIDCFlags) Enabled() bool {
+	return len(f.IssuerURL) > 0
+}
+
+// Validate checks that the OIDC flags are internally consistent.
+func (f *OIDCFlags) Validate() error {
+	if !f.Enabled() {
+		return nil
+	}
+	if len(f.ClientID) == 0 {
+		return fmt.Errorf("--oidc-client-id is required when --oidc-issuer-url is set")
+	}
+	return nil
+}
+
+type discoveryDocument struct {
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+func discover(issuerURL string) (*discoveryDocument, error) {
+	resp, err := http.Get(strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover OIDC provider %s: %v", issuerURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to discover OIDC provider %s: unexpected status %s", issuerURL, resp.Status)
+	}
+
+	doc := &discoveryDocument{}
+	if err := json.NewDecoder(resp.Body).Decode(doc); err != nil {
+		return nil, fmt.Errorf("unable to parse OIDC discovery document: %v", err)
+	}
+	if len(doc.DeviceAuthorizationEndpoint) == 0 {
+		return nil, fmt.Errorf("OIDC provider %s does not advertise a device_authorization_endpoint", issuerURL)
+	}
+	return doc, nil
+}
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+// RunDeviceFlow performs the OAuth2 device authorization grant (RFC 8628),
+// with a PKCE (RFC 7636) S256 code challenge, against the IdP named by f, prints the verification URL and code for the
+// user to complete in a browser, polls until they do, and stores the
+// resulting refresh token in the OS keychain.
+func (f *OIDCFlags) RunDeviceFlow(streams genericclioptions.IOStreams) error {
+	doc, err := discover(f.IssuerURL)
+	if err != nil {
+		return err
+	}
+
+	verifierBytes := make([]byte, 32)
+	if _, err := rand.Read(verifierBytes); err != nil {
+		return fmt.Errorf("unable to generate PKCE code verifier: %v", err)
+	}
+	codeVerifier := base64.RawURLEncoding.EncodeToString(verifierBytes)
+	codeChallenge := sha256.Sum256([]byte(codeVerifier))
+
+	form := url.Values{
+		"client_id":             {f.ClientID},
+		"scope":                 {"openid offline_access"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(codeChallenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	resp, err := http.PostForm(doc.DeviceAuthorizationEndpoint, form)
+	if err != nil {
+		return fmt.Errorf("unable to start device authorization: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to start device authorization: unexpected status %s: %s", resp.Status, string(body))
+	}
+
+	device := &deviceCodeResponse{}
+	if err := json.Unmarshal(body, device); err != nil {
+		return fmt.Errorf("unable to parse device authorization response: %v", err)
+	}
+
+	if len(device.VerificationURIComplete) > 0 {
+		fmt.Fprintf(streams.Out, "To authenticate, visit:\n\n    %s\n\n", device.VerificationURIComplete)
+	} else {
+		fmt.Fprintf(streams.Out, "To authenticate, visit:\n\n    %s\n\nand enter code: %s\n\n", device.VerificationURI, device.UserCode)
+	}
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		tokenForm := url.Values{
+			"grant_type":    {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code":   {device.DeviceCode},
+			"client_id":     {f.ClientID},
+			"code_verifier": {codeVerifier},
+		}
+		tokenResp, err := http.PostForm(doc.TokenEndpoint, tokenForm)
+		if err != nil {
+			return fmt.Errorf("unable to poll token endpoint: %v", err)
+		}
+		tokenBody, err := io.ReadAll(tokenResp.Body)
+		tokenResp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		token := &tokenResponse{}
+		if err := json.Unmarshal(tokenBody, token); err != nil {
+			return fmt.Errorf("unable to parse token response: %v", err)
+		}
+
+		switch token.Error {
+		case "":
+			if len(token.RefreshToken) == 0 {
+				return fmt.Errorf("OIDC provider did not return a refresh token; request the offline_access scope")
+			}
+			return keyring.Set(keyringService, keyringAccount(f.IssuerURL, f.ClientID), token.RefreshToken)
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		default:
+			return fmt.Errorf("authorization failed: %s", token.Error)
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for authorization")
+}
+
+// BuildExecConfig returns the kubeconfig users[].exec stanza that refreshes
+// through `oc auth oidc-token`.
+func (f *OIDCFlags) BuildExecConfig() *clientcmdapi.ExecConfig {
+	return &clientcmdapi.ExecConfig{
+		APIVersion: execAPIVersion,
+		Command:    "oc",
+		Args: []string{
+			"auth", "oidc-token",
+			"--issuer-url=" + f.IssuerURL,
+			"--client-id=" + f.ClientID,
+		},
+		InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+	}
+}
+
+func keyringAccount(issuerURL, clientID string) string {
+	return issuerURL + "|" + clientID
+}
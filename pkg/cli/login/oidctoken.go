@@ -0,0 +1,105 @@
+package login
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// execCredential is the client.authentication.k8s.io/v1 ExecCredential
+// response an exec plugin writes to stdout.
+type execCredential struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     execCredentialStatus `json:"status"`
+}
+
+type execCredentialStatus struct {
+	Token               string `json:"token"`
+	ExpirationTimestamp string `json:"expirationTimestamp,omitempty"`
+}
+
+// NewCmdOIDCToken implements `oc auth oidc-token`, the exec plugin that
+// `oc login --oidc-issuer-url` configures as the kubeconfig credential
+// provider. It exchanges the refresh token stashed in the OS keychain for a
+// fresh access token and writes it as an ExecCredential on stdout.
+func NewCmdOIDCToken(streams genericclioptions.IOStreams) *cobra.Command {
+	var issuerURL, clientID string
+
+	cmd := &cobra.Command{
+		Use:    "oidc-token",
+		Short:  "Print a Kubernetes ExecCredential from a stored OIDC refresh token",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOIDCToken(streams, issuerURL, clientID)
+		},
+	}
+	cmd.Flags().StringVar(&issuerURL, "issuer-url", "", "Issuer URL of the OpenID Connect provider")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "OAuth2 client ID registered with --issuer-url")
+	return cmd
+}
+
+func runOIDCToken(streams genericclioptions.IOStreams, issuerURL, clientID string) error {
+	if len(issuerURL) == 0 || len(clientID) == 0 {
+		return fmt.Errorf("--issuer-url and --client-id are required")
+	}
+
+	refreshToken, err := keyring.Get(keyringService, keyringAccount(issuerURL, clientID))
+	if err != nil {
+		return fmt.Errorf("no stored OIDC session for %s; run oc login --oidc-issuer-url again: %v", issuerURL, err)
+	}
+
+	doc, err := discover(issuerURL)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {clientID},
+	}
+	resp, err := http.PostForm(doc.TokenEndpoint, form)
+	if err != nil {
+		return fmt.Errorf("unable to refresh OIDC token: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	token := &tokenResponse{}
+	if err := json.Unmarshal(body, token); err != nil {
+		return fmt.Errorf("unable to parse token response: %v", err)
+	}
+	if len(token.Error) > 0 {
+		return fmt.Errorf("unable to refresh OIDC token: %s", token.Error)
+	}
+
+	if len(token.RefreshToken) > 0 && token.RefreshToken != refreshToken {
+		if err := keyring.Set(keyringService, keyringAccount(issuerURL, clientID), token.RefreshToken); err != nil {
+			fmt.Fprintf(streams.ErrOut, "Warning: unable to persist rotated refresh token: %v\n", err)
+		}
+	}
+
+	cred := execCredential{
+		APIVersion: execAPIVersion,
+		Kind:       "ExecCredential",
+		Status: execCredentialStatus{
+			Token:                token.AccessToken,
+			ExpirationTimestamp: time.Now().UTC().Add(time.Duration(token.ExpiresIn) * time.Second).Format(time.RFC3339),
+		},
+	}
+
+	enc := json.NewEncoder(streams.Out)
+	return enc.Encode(cred)
+}
@@ -0,0 +1,79 @@
+package login
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// WireAuthProviderFlags adds --auth-provider-exec and --oidc-* to an
+// existing `oc login` command. When one of them is set, it takes over the
+// command's RunE: instead of the normal username/password or token flow, it
+// resolves the server the user gave (the same positional argument or
+// --server flag `oc login` itself accepts), establishes a cluster/context
+// for it, and either records the given exec plugin invocation or runs the
+// OIDC device-code flow and points the new context at `oc auth oidc-token`
+// to keep the access token refreshed. Neither branch requires a context to
+// already exist, so this works on a first-ever login.
+func WireAuthProviderFlags(loginCmd *cobra.Command, streams genericclioptions.IOStreams) {
+	execFlags := AddExecFlags(loginCmd)
+	oidcFlags := AddOIDCFlags(loginCmd)
+
+	originalRunE := loginCmd.RunE
+
+	loginCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		switch {
+		case oidcFlags.Enabled():
+			if err := oidcFlags.Validate(); err != nil {
+				return err
+			}
+			server, err := resolveServer(cmd, args)
+			if err != nil {
+				return err
+			}
+			if err := oidcFlags.RunDeviceFlow(streams); err != nil {
+				return err
+			}
+			if err := establishExecContext(cmd, server, "oidc", oidcFlags.BuildExecConfig()); err != nil {
+				return err
+			}
+			fmt.Fprintln(streams.Out, "Login successful. The current context now refreshes credentials via oc auth oidc-token.")
+			return nil
+
+		case execFlags.Enabled:
+			if err := execFlags.Validate(); err != nil {
+				return err
+			}
+			server, err := resolveServer(cmd, args)
+			if err != nil {
+				return err
+			}
+			if err := establishExecContext(cmd, server, execFlags.Command, execFlags.BuildExecConfig()); err != nil {
+				return err
+			}
+			fmt.Fprintln(streams.Out, "Login successful. The current context now authenticates via "+execFlags.Command+".")
+			return nil
+
+		default:
+			if originalRunE != nil {
+				return originalRunE(cmd, args)
+			}
+			return nil
+		}
+	}
+}
+
+// resolveServer returns the server URL the user gave `oc login`, either as
+// its positional argument or via --server, the same two ways the normal
+// username/password flow accepts it.
+func resolveServer(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) > 0 && len(args[0]) > 0 {
+		return args[0], nil
+	}
+	if server, err := cmd.Flags().GetString("server"); err == nil && len(server) > 0 {
+		return server, nil
+	}
+	return "", fmt.Errorf("a server URL is required, e.g. oc login https://api.example.com:6443 --auth-provider-exec ...")
+}
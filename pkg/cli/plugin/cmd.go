@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// NewCmdManage returns the `oc plugin search|install|upgrade|uninstall`
+// subcommands of the Krew-style plugin package manager; `oc plugin list
+// --available` is added separately by AddAvailableFlag. Callers add them to
+// the command returned by the upstream `oc plugin` command (kubectl's plugin
+// listing command) so the index-aware subcommands live alongside
+// `oc plugin list`.
+func NewCmdManage(f kcmdutil.Factory, streams genericclioptions.IOStreams) []*cobra.Command {
+	return []*cobra.Command{
+		NewCmdSearch(streams),
+		NewCmdInstall(streams),
+		NewCmdUpgrade(streams),
+		NewCmdUninstall(streams),
+	}
+}
+
+// AddAvailableFlag adds --available to an existing `oc plugin list`
+// command, switching it to report plugins in the index rather than the ones
+// already on $PATH.
+func AddAvailableFlag(listCmd *cobra.Command, streams genericclioptions.IOStreams) {
+	o := &ListAvailableOptions{IOStreams: streams}
+	var available bool
+
+	originalRunE := listCmd.RunE
+	originalRun := listCmd.Run
+
+	listCmd.Flags().StringVar(&o.IndexURL, "index-url", "", "URL of the plugin index to query (defaults to "+DefaultIndexURL+", overridable via OC_PLUGIN_INDEX)")
+	listCmd.Flags().BoolVar(&available, "available", false, "List plugins published in the plugin index instead of those already installed")
+
+	listCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if available {
+			return o.Run()
+		}
+		if originalRunE != nil {
+			return originalRunE(cmd, args)
+		}
+		if originalRun != nil {
+			originalRun(cmd, args)
+		}
+		return nil
+	}
+}
+
+var searchExample = ktemplates.Examples(`
+	# Search the plugin index for plugins matching a substring
+	oc plugin search registry
+
+	# Search a custom plugin index
+	oc plugin search --index-url=https://example.com/plugins.yaml registry
+`)
+
+// NewCmdSearch implements `oc plugin search <query>`.
+func NewCmdSearch(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &SearchOptions{IOStreams: streams}
+
+	cmd := &cobra.Command{
+		Use:     "search [query]",
+		Short:   "Search the plugin index for available plugins",
+		Example: searchExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.Query = ""
+			if len(args) > 0 {
+				o.Query = args[0]
+			}
+			return o.Run()
+		},
+	}
+	cmd.Flags().StringVar(&o.IndexURL, "index-url", "", "URL of the plugin index to query (defaults to "+DefaultIndexURL+", overridable via OC_PLUGIN_INDEX)")
+	return cmd
+}
+
+var installExample = ktemplates.Examples(`
+	# Install the latest published version of a plugin
+	oc plugin install registry-login
+
+	# Install a specific version of a plugin
+	oc plugin install registry-login --version=v1.2.0
+`)
+
+// NewCmdInstall implements `oc plugin install <name>`.
+func NewCmdInstall(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &InstallOptions{IOStreams: streams}
+
+	cmd := &cobra.Command{
+		Use:     "install NAME",
+		Short:   "Install a plugin from the plugin index",
+		Example: installExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(args); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+	cmd.Flags().StringVar(&o.IndexURL, "index-url", "", "URL of the plugin index to query (defaults to "+DefaultIndexURL+", overridable via OC_PLUGIN_INDEX)")
+	cmd.Flags().StringVar(&o.Version, "version", "", "Plugin version to install (defaults to the newest published version)")
+	return cmd
+}
+
+var upgradeExample = ktemplates.Examples(`
+	# Upgrade a plugin to the newest published version
+	oc plugin upgrade registry-login
+`)
+
+// NewCmdUpgrade implements `oc plugin upgrade <name>`.
+func NewCmdUpgrade(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &InstallOptions{IOStreams: streams, upgrade: true}
+
+	cmd := &cobra.Command{
+		Use:     "upgrade NAME",
+		Short:   "Upgrade an installed plugin to the newest published version",
+		Example: upgradeExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(args); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+	cmd.Flags().StringVar(&o.IndexURL, "index-url", "", "URL of the plugin index to query (defaults to "+DefaultIndexURL+", overridable via OC_PLUGIN_INDEX)")
+	return cmd
+}
+
+var uninstallExample = ktemplates.Examples(`
+	# Remove an installed plugin
+	oc plugin uninstall registry-login
+`)
+
+// NewCmdUninstall implements `oc plugin uninstall <name>`.
+func NewCmdUninstall(streams genericclioptions.IOStreams) *cobra.Command {
+	o := &UninstallOptions{IOStreams: streams}
+
+	cmd := &cobra.Command{
+		Use:     "uninstall NAME",
+		Short:   "Remove an installed plugin",
+		Example: uninstallExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(args); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+	return cmd
+}
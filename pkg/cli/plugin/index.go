@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// IndexClient fetches and parses the plugin index.
+type IndexClient struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewIndexClient returns an IndexClient for url, or for the default index
+// (DefaultIndexURL, overridable via OC_PLUGIN_INDEX) when url is empty.
+func NewIndexClient(url string) *IndexClient {
+	if len(url) == 0 {
+		if envURL := os.Getenv(IndexURLEnvVar); len(envURL) > 0 {
+			url = envURL
+		} else {
+			url = DefaultIndexURL
+		}
+	}
+	return &IndexClient{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Fetch downloads and parses the index document.
+func (c *IndexClient) Fetch() (*Index, error) {
+	resp, err := c.HTTPClient.Get(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch plugin index from %s: %v", c.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch plugin index from %s: unexpected status %s", c.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read plugin index from %s: %v", c.URL, err)
+	}
+
+	index := &Index{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("unable to parse plugin index from %s: %v", c.URL, err)
+	}
+	return index, nil
+}
+
+// Find returns the manifest for name, if the index contains one.
+func (idx *Index) Find(name string) (Manifest, bool) {
+	for _, m := range idx.Plugins {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Manifest{}, false
+}
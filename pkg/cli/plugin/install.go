@@ -0,0 +1,273 @@
+package plugin
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Installer downloads, verifies, and extracts a plugin Platform artifact
+// into BinDir.
+type Installer struct {
+	HTTPClient *http.Client
+	// VerifySig, when set, is called with the archive path and the
+	// platform's Sig URI to verify a cosign signature before extraction.
+	// Always nil for now - cosign verification isn't implemented yet, so
+	// there's no flag wiring it up; see the plugin package's install_test.go
+	// for the sha256 check that does run unconditionally.
+	VerifySig func(archivePath, sigURI string) error
+}
+
+// NewInstaller returns an Installer with sane defaults.
+func NewInstaller() *Installer {
+	return &Installer{HTTPClient: &http.Client{Timeout: 5 * time.Minute}}
+}
+
+// CurrentPlatform returns the Platform entry in v matching the running
+// GOOS/GOARCH.
+func CurrentPlatform(v Version) (Platform, error) {
+	p, ok := v.ForPlatform(runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return Platform{}, fmt.Errorf("no plugin artifact published for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	return p, nil
+}
+
+// Install downloads platform's archive, verifies its checksum (and
+// signature, if VerifySig is set and platform.Sig is non-empty), extracts
+// platform.Bin into BinDir as kubectl-<name> (with any dashes in name
+// written as underscores), and returns the installed binary's path.
+func (i *Installer) Install(name string, platform Platform) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "oc-plugin-install-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archivePath := filepath.Join(tmpDir, filepath.Base(platform.URI))
+	if err := i.download(platform.URI, archivePath); err != nil {
+		return "", err
+	}
+
+	if err := verifySHA256(archivePath, platform.SHA256); err != nil {
+		return "", err
+	}
+
+	if i.VerifySig != nil && len(platform.Sig) > 0 {
+		if err := i.VerifySig(archivePath, platform.Sig); err != nil {
+			return "", fmt.Errorf("signature verification failed for %s: %v", name, err)
+		}
+	}
+
+	extractDir := filepath.Join(tmpDir, "extracted")
+	if err := extractArchive(archivePath, extractDir); err != nil {
+		return "", err
+	}
+
+	binDir, err := BinDir()
+	if err != nil {
+		return "", err
+	}
+
+	src := filepath.Join(extractDir, platform.Bin)
+	destName := "kubectl-" + strings.ReplaceAll(name, "-", "_")
+	if runtime.GOOS == "windows" {
+		destName += ".exe"
+	}
+	dest := filepath.Join(binDir, destName)
+
+	if err := copyExecutable(src, dest); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// Remove deletes the installed binary for name.
+func Remove(name string) error {
+	binDir, err := BinDir()
+	if err != nil {
+		return err
+	}
+	destName := "kubectl-" + strings.ReplaceAll(name, "-", "_")
+	if runtime.GOOS == "windows" {
+		destName += ".exe"
+	}
+	err = os.Remove(filepath.Join(binDir, destName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (i *Installer) download(url, dest string) error {
+	resp, err := i.HTTPClient.Get(url)
+	if err != nil {
+		return fmt.Errorf("unable to download %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download %s: unexpected status %s", url, resp.Status)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+func verifySHA256(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+func extractArchive(archivePath, destDir string) error {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	switch {
+	case strings.HasSuffix(archivePath, ".zip"):
+		return extractZip(archivePath, destDir)
+	case strings.HasSuffix(archivePath, ".tar.gz"), strings.HasSuffix(archivePath, ".tgz"):
+		return extractTarGz(archivePath, destDir)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", filepath.Base(archivePath))
+	}
+}
+
+func extractZip(archivePath, destDir string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		dest := filepath.Join(destDir, f.Name)
+		if !strings.HasPrefix(dest, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal file path in archive: %s", f.Name)
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		src, err := f.Open()
+		if err != nil {
+			return err
+		}
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.Mode())
+		if err != nil {
+			src.Close()
+			return err
+		}
+		_, copyErr := io.Copy(out, src)
+		src.Close()
+		out.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+	}
+	return nil
+}
+
+func extractTarGz(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		dest := filepath.Join(destDir, hdr.Name)
+		if !strings.HasPrefix(dest, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal file path in archive: %s", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			_, copyErr := io.Copy(out, tr)
+			out.Close()
+			if copyErr != nil {
+				return copyErr
+			}
+		}
+	}
+}
+
+func copyExecutable(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("plugin archive does not contain %s: %v", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
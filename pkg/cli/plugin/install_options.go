@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"fmt"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// InstallOptions holds the inputs shared by `oc plugin install` and
+// `oc plugin upgrade` (upgrade is install of the newest version onto an
+// already-installed plugin).
+type InstallOptions struct {
+	genericclioptions.IOStreams
+
+	IndexURL string
+	Name     string
+	Version  string
+
+	upgrade bool
+}
+
+// Complete fills in Name from args.
+func (o *InstallOptions) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one plugin name is required")
+	}
+	o.Name = args[0]
+	return nil
+}
+
+// Run resolves the requested plugin/version against the index, installs it,
+// and records a receipt so `list` and `uninstall` know about it.
+func (o *InstallOptions) Run() error {
+	index, err := NewIndexClient(o.IndexURL).Fetch()
+	if err != nil {
+		return err
+	}
+
+	manifest, ok := index.Find(o.Name)
+	if !ok {
+		return fmt.Errorf("no plugin named %q in the index", o.Name)
+	}
+
+	if o.upgrade {
+		if _, installed, err := ReadReceipt(o.Name); err != nil {
+			return err
+		} else if !installed {
+			return fmt.Errorf("plugin %q is not installed", o.Name)
+		}
+	}
+
+	version, err := o.resolveVersion(manifest)
+	if err != nil {
+		return err
+	}
+
+	platform, err := CurrentPlatform(version)
+	if err != nil {
+		return err
+	}
+
+	installer := NewInstaller()
+
+	binPath, err := installer.Install(o.Name, platform)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteReceipt(Receipt{Name: o.Name, Version: version.Version, Bin: binPath}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Installed plugin %q (%s) to %s\n", o.Name, version.Version, binPath)
+	if len(manifest.Caveats) > 0 {
+		fmt.Fprintf(o.Out, "Caveats:\n  %s\n", manifest.Caveats)
+	}
+	return nil
+}
+
+func (o *InstallOptions) resolveVersion(manifest Manifest) (Version, error) {
+	if len(o.Version) == 0 {
+		latest, ok := manifest.Latest()
+		if !ok {
+			return Version{}, fmt.Errorf("plugin %q has no published versions", o.Name)
+		}
+		return latest, nil
+	}
+
+	for _, v := range manifest.Versions {
+		if v.Version == o.Version {
+			return v, nil
+		}
+	}
+	return Version{}, fmt.Errorf("plugin %q has no published version %q", o.Name, o.Version)
+}
@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifySHA256(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "archive.tar.gz")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("hello"))
+	expected := hex.EncodeToString(sum[:])
+
+	if err := verifySHA256(path, expected); err != nil {
+		t.Errorf("verifySHA256 with a matching checksum returned an error: %v", err)
+	}
+	if err := verifySHA256(path, "0000000000000000000000000000000000000000000000000000000000000000"); err == nil {
+		t.Error("verifySHA256 with a mismatched checksum returned nil")
+	}
+}
+
+func TestExtractZipRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "evil.zip")
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("../../etc/passwd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("pwned")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := filepath.Join(dir, "extracted")
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractZip(archivePath, destDir); err == nil {
+		t.Error("extractZip with a path-traversing entry returned nil, want an error")
+	}
+}
+
+func TestExtractTarGzRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "evil.tar.gz")
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	content := []byte("pwned")
+	if err := tw.WriteHeader(&tar.Header{Name: "../../etc/passwd", Size: int64(len(content)), Mode: 0644}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := filepath.Join(dir, "extracted")
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractTarGz(archivePath, destDir); err == nil {
+		t.Error("extractTarGz with a path-traversing entry returned nil, want an error")
+	}
+}
+
+func TestExtractZipWritesWithinDestDir(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "good.zip")
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("kubectl-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("#!/bin/sh\necho hi\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := filepath.Join(dir, "extracted")
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractZip(archivePath, destDir); err != nil {
+		t.Fatalf("extractZip: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "kubectl-plugin")); err != nil {
+		t.Errorf("expected extracted file, got: %v", err)
+	}
+}
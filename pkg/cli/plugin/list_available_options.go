@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// ListAvailableOptions holds the inputs for `oc plugin list --available`.
+type ListAvailableOptions struct {
+	genericclioptions.IOStreams
+
+	IndexURL string
+}
+
+// Run prints every plugin in the index alongside its installed state.
+func (o *ListAvailableOptions) Run() error {
+	index, err := NewIndexClient(o.IndexURL).Fetch()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.Out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "NAME\tLATEST\tINSTALLED")
+
+	for _, m := range index.Plugins {
+		latest, ok := m.Latest()
+		latestVersion := ""
+		if ok {
+			latestVersion = latest.Version
+		}
+
+		installedVersion := "-"
+		if receipt, installed, err := ReadReceipt(m.Name); err == nil && installed {
+			installedVersion = receipt.Version
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", m.Name, latestVersion, installedVersion)
+	}
+	return nil
+}
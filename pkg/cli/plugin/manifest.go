@@ -0,0 +1,76 @@
+// Package plugin implements a Krew-compatible plugin package manager for
+// oc: searching, installing, upgrading, and removing plugins published to a
+// YAML index, layered on top of the kubectl plugin lookup mechanism.
+package plugin
+
+// DefaultIndexURL is used when neither --index-url nor OC_PLUGIN_INDEX is
+// set. It points at the OpenShift-maintained mirror of the plugin index.
+const DefaultIndexURL = "https://raw.githubusercontent.com/openshift/oc-plugins-index/master/plugins.yaml"
+
+// IndexURLEnvVar overrides DefaultIndexURL when set.
+const IndexURLEnvVar = "OC_PLUGIN_INDEX"
+
+// Index is the top-level document served from the plugin index URL.
+type Index struct {
+	Plugins []Manifest `json:"plugins" yaml:"plugins"`
+}
+
+// Manifest describes a single installable plugin and every platform it is
+// published for.
+type Manifest struct {
+	// Name is the plugin name as passed to `oc plugin install <name>`; the
+	// installed binary is exposed as kubectl-<name> per the kubectl plugin
+	// naming convention.
+	Name string `json:"name" yaml:"name"`
+	// ShortDescription is shown by `oc plugin search`.
+	ShortDescription string `json:"shortDescription" yaml:"shortDescription"`
+	// Caveats is freeform text shown to the user after a successful
+	// install, e.g. required follow-up configuration.
+	Caveats string `json:"caveats,omitempty" yaml:"caveats,omitempty"`
+	// Versions are offered newest-first; Versions[0] is installed unless
+	// --version is given to `oc plugin install`.
+	Versions []Version `json:"versions" yaml:"versions"`
+}
+
+// Version is one released version of a plugin, available for one or more
+// platforms.
+type Version struct {
+	Version   string     `json:"version" yaml:"version"`
+	Platforms []Platform `json:"platforms" yaml:"platforms"`
+}
+
+// Platform is a single downloadable artifact for a given OS/architecture.
+type Platform struct {
+	// OS and Arch match runtime.GOOS / runtime.GOARCH, e.g. "linux"/"amd64".
+	OS   string `json:"os" yaml:"os"`
+	Arch string `json:"arch" yaml:"arch"`
+	// URI is the archive to download.
+	URI string `json:"uri" yaml:"uri"`
+	// SHA256 is the required checksum of the archive at URI.
+	SHA256 string `json:"sha256" yaml:"sha256"`
+	// Sig, if set, is the URI of a cosign signature for URI. Install checks
+	// it only through Installer.VerifySig, which nothing in oc sets, so the
+	// signature is not verified.
+	Sig string `json:"sig,omitempty" yaml:"sig,omitempty"`
+	// Bin is the path of the plugin executable inside the extracted
+	// archive, e.g. "kubectl-foo" or "bin/kubectl-foo".
+	Bin string `json:"bin" yaml:"bin"`
+}
+
+// ForPlatform returns the Platform entry matching os/arch, if any.
+func (v Version) ForPlatform(os, arch string) (Platform, bool) {
+	for _, p := range v.Platforms {
+		if p.OS == os && p.Arch == arch {
+			return p, true
+		}
+	}
+	return Platform{}, false
+}
+
+// Latest returns the newest published Version, if any.
+func (m Manifest) Latest() (Version, bool) {
+	if len(m.Versions) == 0 {
+		return Version{}, false
+	}
+	return m.Versions[0], true
+}
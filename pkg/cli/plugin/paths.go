@@ -0,0 +1,48 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// BaseDir returns ~/.oc/plugins, creating it if necessary.
+func BaseDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".oc", "plugins")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// BinDir returns ~/.oc/plugins/bin, the directory prepended to the plugin
+// lookup path, creating it if necessary.
+func BinDir() (string, error) {
+	base, err := BaseDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "bin")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// ReceiptsDir returns ~/.oc/plugins/receipts, the directory used to record
+// which version of each plugin is currently installed, creating it if
+// necessary.
+func ReceiptsDir() (string, error) {
+	base, err := BaseDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "receipts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
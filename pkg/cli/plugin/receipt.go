@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Receipt records the installed version of a plugin so `upgrade`,
+// `uninstall`, and `list` don't need to re-fetch the index to know what is
+// on disk.
+type Receipt struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	Bin     string `json:"bin" yaml:"bin"`
+}
+
+func receiptPath(name string) (string, error) {
+	dir, err := ReceiptsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
+
+// WriteReceipt persists r to the receipts directory.
+func WriteReceipt(r Receipt) error {
+	path, err := receiptPath(r.Name)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadReceipt loads the receipt for name, if one exists.
+func ReadReceipt(name string) (Receipt, bool, error) {
+	path, err := receiptPath(name)
+	if err != nil {
+		return Receipt{}, false, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Receipt{}, false, nil
+	}
+	if err != nil {
+		return Receipt{}, false, err
+	}
+	r := Receipt{}
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return Receipt{}, false, err
+	}
+	return r, true, nil
+}
+
+// RemoveReceipt deletes the receipt for name, if one exists.
+func RemoveReceipt(name string) error {
+	path, err := receiptPath(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// ListReceipts returns every installed plugin's receipt.
+func ListReceipts() ([]Receipt, error) {
+	dir, err := ReceiptsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []Receipt
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		r := Receipt{}
+		if err := yaml.Unmarshal(data, &r); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, r)
+	}
+	return receipts, nil
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// SearchOptions holds the inputs for `oc plugin search`.
+type SearchOptions struct {
+	genericclioptions.IOStreams
+
+	IndexURL string
+	Query    string
+}
+
+// Run fetches the index and prints every plugin whose name or short
+// description contains Query (or every plugin, if Query is empty).
+func (o *SearchOptions) Run() error {
+	index, err := NewIndexClient(o.IndexURL).Fetch()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.Out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "NAME\tDESCRIPTION")
+
+	query := strings.ToLower(o.Query)
+	found := 0
+	for _, m := range index.Plugins {
+		if len(query) > 0 &&
+			!strings.Contains(strings.ToLower(m.Name), query) &&
+			!strings.Contains(strings.ToLower(m.ShortDescription), query) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\n", m.Name, m.ShortDescription)
+		found++
+	}
+
+	if found == 0 {
+		fmt.Fprintln(o.ErrOut, "No plugins found matching the given query")
+	}
+	return nil
+}
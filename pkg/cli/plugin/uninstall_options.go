@@ -0,0 +1,42 @@
+package plugin
+
+import (
+	"fmt"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// UninstallOptions holds the inputs for `oc plugin uninstall`.
+type UninstallOptions struct {
+	genericclioptions.IOStreams
+
+	Name string
+}
+
+// Complete fills in Name from args.
+func (o *UninstallOptions) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one plugin name is required")
+	}
+	o.Name = args[0]
+	return nil
+}
+
+// Run removes the plugin's binary and receipt.
+func (o *UninstallOptions) Run() error {
+	if _, installed, err := ReadReceipt(o.Name); err != nil {
+		return err
+	} else if !installed {
+		return fmt.Errorf("plugin %q is not installed", o.Name)
+	}
+
+	if err := Remove(o.Name); err != nil {
+		return err
+	}
+	if err := RemoveReceipt(o.Name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Uninstalled plugin %q\n", o.Name)
+	return nil
+}
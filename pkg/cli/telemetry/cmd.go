@@ -0,0 +1,29 @@
+package telemetry
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmdSetTelemetry implements `oc config set-telemetry on|off`, the
+// opt-in switch IsEnabled reads.
+func NewCmdSetTelemetry(streams genericclioptions.IOStreams) *cobra.Command {
+	return &cobra.Command{
+		Use:       "set-telemetry on|off",
+		Short:     "Enable or disable local command-usage telemetry",
+		Long:      "Controls whether oc records command usage (command path, flag names, duration, exit code, server version, and warning count - never resource names or values) to ~/.oc/history.jsonl and, if OC_OTLP_ENDPOINT is set, an OTLP collector. Off by default.",
+		ValidArgs: []string{"on", "off"},
+		Args:      cobra.ExactValidArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			enabled := args[0] == "on"
+			if err := SetEnabled(enabled); err != nil {
+				return err
+			}
+			fmt.Fprintf(streams.Out, "Telemetry is now %s.\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,64 @@
+// Package telemetry records an opt-in, anonymized event for each oc
+// invocation - command path, flag names, duration, exit code, server
+// version, and warning count - to a local JSONL history and, optionally, an
+// OTLP endpoint. It never records resource names, flag values, or other
+// content that could identify what a user worked on.
+package telemetry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// settings is persisted to ~/.oc/telemetry.json by `oc config
+// set-telemetry`.
+type settings struct {
+	Enabled bool `json:"enabled"`
+}
+
+func settingsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".oc", "telemetry.json"), nil
+}
+
+// IsEnabled reports whether telemetry has been turned on via `oc config
+// set-telemetry on`. Telemetry is off by default and on any error reading
+// the settings file, erring on the side of not collecting anything.
+func IsEnabled() bool {
+	path, err := settingsPath()
+	if err != nil {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	s := settings{}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return false
+	}
+	return s.Enabled
+}
+
+// SetEnabled persists the on/off setting read by IsEnabled.
+func SetEnabled(enabled bool) error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(settings{Enabled: enabled})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
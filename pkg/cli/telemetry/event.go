@@ -0,0 +1,36 @@
+package telemetry
+
+import "time"
+
+// Event is a single command invocation record. Every field is safe to
+// export outside the cluster: no resource names, no flag values, no
+// kubeconfig contents.
+type Event struct {
+	// CommandPath is the cobra command path, e.g. "oc get".
+	CommandPath string `json:"commandPath"`
+	// Flags is the list of flag *names* that were set, never their values.
+	Flags []string `json:"flags,omitempty"`
+	// DurationMS is how long the command ran for.
+	DurationMS int64 `json:"durationMs"`
+	// ExitCode is 0 for success, matching the process exit code.
+	ExitCode int `json:"exitCode"`
+	// ServerVersion is the apiserver's reported git version, if the
+	// command contacted one.
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// WarningCount is how many distinct server warnings were observed;
+	// see pkg/cli/warnings.
+	WarningCount int `json:"warningCount"`
+	// ClusterURLHash and NamespaceHash are truncated SHA-256 hashes, never
+	// the underlying values, so invocations from the same cluster/project
+	// can be correlated without exposing either.
+	ClusterURLHash string `json:"clusterUrlHash,omitempty"`
+	NamespaceHash  string `json:"namespaceHash,omitempty"`
+	// Timestamp is when the command started, in RFC3339.
+	Timestamp string `json:"timestamp"`
+}
+
+// Finish fills in an Event's duration and exit code at command completion.
+func (e *Event) Finish(start time.Time, exitCode int) {
+	e.DurationMS = time.Since(start).Milliseconds()
+	e.ExitCode = exitCode
+}
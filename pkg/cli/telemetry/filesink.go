@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// maxHistoryBytes is the size history.jsonl is allowed to reach before
+// FileSink rotates it to history.jsonl.1, overwriting any previous
+// rotation.
+const maxHistoryBytes = 10 * 1024 * 1024
+
+// FileSink appends each Event as one line of JSON to ~/.oc/history.jsonl,
+// read by `oc history`.
+type FileSink struct {
+	Path string
+}
+
+// NewFileSink returns a FileSink writing to ~/.oc/history.jsonl.
+func NewFileSink() (*FileSink, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(home, ".oc")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileSink{Path: filepath.Join(dir, "history.jsonl")}, nil
+}
+
+// Record appends event to Path, rotating first if it has grown past
+// maxHistoryBytes.
+func (s *FileSink) Record(event Event) error {
+	if err := s.rotateIfNeeded(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(data)
+	return err
+}
+
+func (s *FileSink) rotateIfNeeded() error {
+	info, err := os.Stat(s.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Size() < maxHistoryBytes {
+		return nil
+	}
+	return os.Rename(s.Path, s.Path+".1")
+}
@@ -0,0 +1,17 @@
+package telemetry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// hashTruncated returns the first 16 hex characters of sha256(value), or
+// "" for an empty value - enough to correlate invocations without
+// exposing the cluster URL or namespace itself.
+func hashTruncated(value string) string {
+	if len(value) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:16]
+}
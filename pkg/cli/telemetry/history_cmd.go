@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmdHistory implements `oc history`, a reader for the JSONL file
+// FileSink writes to.
+func NewCmdHistory(streams genericclioptions.IOStreams) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history",
+		Short: "Show recently run oc commands recorded by telemetry",
+		Long:  "Prints the contents of ~/.oc/history.jsonl, the local record kept when telemetry is enabled via oc config set-telemetry on. Empty if telemetry has never been enabled.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(streams)
+		},
+	}
+}
+
+func runHistory(streams genericclioptions.IOStreams) error {
+	sink, err := NewFileSink()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(sink.Path)
+	if os.IsNotExist(err) {
+		fmt.Fprintln(streams.Out, "No history recorded yet; enable it with 'oc config set-telemetry on'.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "TIMESTAMP\tCOMMAND\tDURATION(ms)\tEXIT")
+
+	decoder := bufio.NewScanner(f)
+	for decoder.Scan() {
+		event, err := decodeEvent(decoder.Bytes())
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", event.Timestamp, event.CommandPath, event.DurationMS, event.ExitCode)
+	}
+	return decoder.Err()
+}
+
+func decodeEvent(line []byte) (Event, error) {
+	var event Event
+	err := json.Unmarshal(line, &event)
+	return event, err
+}
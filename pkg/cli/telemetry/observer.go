@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	kcmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// Observer dispatches a finished Event to every configured Sink. A nil or
+// zero-value Observer (no sinks) is a valid no-op, so callers don't need to
+// branch on whether telemetry is enabled.
+type Observer struct {
+	sinks []Sink
+}
+
+// NewObserver returns an Observer wired up per IsEnabled and
+// OTLPEndpointEnvVar. If telemetry isn't enabled, it returns a no-op
+// Observer rather than an error, since failing to construct it shouldn't
+// block command execution.
+func NewObserver() *Observer {
+	if !IsEnabled() {
+		return &Observer{}
+	}
+
+	o := &Observer{}
+	if fileSink, err := NewFileSink(); err == nil {
+		o.sinks = append(o.sinks, fileSink)
+	}
+	if endpoint := os.Getenv(OTLPEndpointEnvVar); len(endpoint) > 0 {
+		o.sinks = append(o.sinks, NewOTLPSink(endpoint))
+	}
+	return o
+}
+
+// Active reports whether any sink is configured, so callers can skip work
+// (like fetching the server version) that only telemetry needs.
+func (o *Observer) Active() bool {
+	return o != nil && len(o.sinks) > 0
+}
+
+// Invocation tracks one in-flight command's start time so Finish can
+// compute its duration.
+type Invocation struct {
+	observer *Observer
+	event    Event
+	start    time.Time
+}
+
+// Start begins tracking cmd. f is used to resolve the cluster URL and
+// namespace for ClusterURLHash/NamespaceHash and may be nil, in which case
+// those fields are left empty. Safe to call even when the Observer has no
+// sinks configured - Finish on the result is then a no-op.
+func (o *Observer) Start(cmd *cobra.Command, f kcmdutil.Factory) *Invocation {
+	inv := &Invocation{observer: o, start: time.Now()}
+	if o == nil || len(o.sinks) == 0 {
+		return inv
+	}
+
+	inv.event = Event{
+		CommandPath: cmd.CommandPath(),
+		Timestamp:   inv.start.UTC().Format(time.RFC3339),
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		inv.event.Flags = append(inv.event.Flags, f.Name)
+	})
+
+	if f != nil {
+		if restConfig, err := f.ToRESTConfig(); err == nil {
+			inv.event.ClusterURLHash = hashTruncated(restConfig.Host)
+		}
+		if namespace, _, err := f.ToRawKubeConfigLoader().Namespace(); err == nil {
+			inv.event.NamespaceHash = hashTruncated(namespace)
+		}
+	}
+	return inv
+}
+
+// Finish records the invocation's duration and exit code and dispatches it
+// to every sink, best-effort; sink errors are swallowed so a broken
+// telemetry endpoint never fails the command it's observing.
+func (i *Invocation) Finish(exitCode int, serverVersion string, warningCount int) {
+	if i.observer == nil || len(i.observer.sinks) == 0 {
+		return
+	}
+
+	i.event.Finish(i.start, exitCode)
+	i.event.ServerVersion = serverVersion
+	i.event.WarningCount = warningCount
+
+	for _, sink := range i.observer.sinks {
+		_ = sink.Record(i.event)
+	}
+}
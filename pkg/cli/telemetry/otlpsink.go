@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OTLPEndpointEnvVar enables the OTLP sink when set, pointing at an
+// OTLP/HTTP traces endpoint (e.g. "http://localhost:4318/v1/traces").
+const OTLPEndpointEnvVar = "OC_OTLP_ENDPOINT"
+
+// OTLPSink exports one span per invocation to an OTLP/HTTP collector using
+// the collector's JSON encoding directly, rather than pulling in the full
+// OpenTelemetry SDK for a single span per process lifetime.
+type OTLPSink struct {
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// NewOTLPSink returns an OTLPSink posting to endpoint.
+func NewOTLPSink(endpoint string) *OTLPSink {
+	return &OTLPSink{
+		Endpoint:   endpoint,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Record posts event as a single-span OTLP trace export request.
+func (s *OTLPSink) Record(event Event) error {
+	body, err := json.Marshal(buildExportRequest(event))
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.HTTPClient.Post(s.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to export telemetry to %s: %v", s.Endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unable to export telemetry to %s: unexpected status %s", s.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// The structs below mirror the minimal subset of the OTLP traces JSON
+// schema needed for a single span; see
+// opentelemetry-proto/opentelemetry/proto/trace/v1/trace.proto.
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource    `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	Name              string          `json:"name"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string        `json:"key"`
+	Value otlpAttrValue `json:"value"`
+}
+
+type otlpAttrValue struct {
+	StringValue string `json:"stringValue,omitempty"`
+	IntValue    string `json:"intValue,omitempty"`
+}
+
+func stringAttr(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAttrValue{StringValue: value}}
+}
+
+func intAttr(key string, value int64) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAttrValue{IntValue: fmt.Sprintf("%d", value)}}
+}
+
+func buildExportRequest(event Event) otlpExportRequest {
+	end := time.Now()
+	start := end.Add(-time.Duration(event.DurationMS) * time.Millisecond)
+
+	attrs := []otlpAttribute{
+		intAttr("exit_code", int64(event.ExitCode)),
+		intAttr("warning_count", int64(event.WarningCount)),
+	}
+	if len(event.ServerVersion) > 0 {
+		attrs = append(attrs, stringAttr("server_version", event.ServerVersion))
+	}
+	if len(event.ClusterURLHash) > 0 {
+		attrs = append(attrs, stringAttr("cluster_url_hash", event.ClusterURLHash))
+	}
+	if len(event.NamespaceHash) > 0 {
+		attrs = append(attrs, stringAttr("namespace_hash", event.NamespaceHash))
+	}
+	for _, flag := range event.Flags {
+		attrs = append(attrs, stringAttr("flag", flag))
+	}
+
+	return otlpExportRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: []otlpAttribute{stringAttr("service.name", "oc")}},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/openshift/oc/pkg/cli/telemetry"},
+				Spans: []otlpSpan{{
+					Name:              event.CommandPath,
+					StartTimeUnixNano: fmt.Sprintf("%d", start.UnixNano()),
+					EndTimeUnixNano:   fmt.Sprintf("%d", end.UnixNano()),
+					Attributes:        attrs,
+				}},
+			}},
+		}},
+	}
+}
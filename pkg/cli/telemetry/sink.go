@@ -0,0 +1,8 @@
+package telemetry
+
+// Sink records a finished Event somewhere - a local file, a remote
+// collector, etc. Record should not block command exit for long; sinks
+// that talk to the network should apply their own short timeout.
+type Sink interface {
+	Record(Event) error
+}
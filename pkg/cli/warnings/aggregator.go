@@ -0,0 +1,112 @@
+// Package warnings buffers server warning headers (as surfaced by
+// rest.WarningHandler) so they can be rendered as a structured document in
+// addition to the human-readable stream the client prints today.
+package warnings
+
+import (
+	"context"
+	"sync"
+)
+
+// Entry records a single deduplicated warning observed on the wire.
+type Entry struct {
+	// Code is the warning code from the Warning response header (299 for
+	// the generic "miscellaneous persistent warning" class used by the
+	// Kubernetes API machinery).
+	Code int `json:"code" yaml:"code"`
+	// Agent identifies the component that produced the warning, when the
+	// server sets it; usually empty for API server warnings.
+	Agent string `json:"agent,omitempty" yaml:"agent,omitempty"`
+	// Text is the warning message as sent by the server.
+	Text string `json:"text" yaml:"text"`
+	// Count is how many times this exact warning was observed. Warnings
+	// are deduplicated by agent and text.
+	Count int `json:"count" yaml:"count"`
+	// Verb and URL identify the request that produced the warning, e.g.
+	// "PUT" and "/apis/apps/v1/namespaces/ns/deployments/x". Empty unless
+	// the handler was reached via WrapTransport - rest.WarningHandler's
+	// plain HandleWarningHeader carries no request information at all.
+	Verb string `json:"verb,omitempty" yaml:"verb,omitempty"`
+	URL  string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Resource is the best-effort group/version/resource parsed from URL,
+	// e.g. "apps/v1, resource=deployments". This is the resource (plural),
+	// not the Kind: recovering the Kind needs a RESTMapping lookup this
+	// package has no access to. Empty under the same conditions as Verb
+	// and URL, or if URL didn't parse as a Kubernetes API request path.
+	Resource string `json:"resource,omitempty" yaml:"resource,omitempty"`
+}
+
+// Aggregator implements rest.WarningHandlerWithContext (and, for callers
+// that only support the older rest.WarningHandler, that too) and retains
+// every distinct warning it receives so a structured summary can be
+// produced at process exit, instead of only a scrolling stderr stream.
+type Aggregator struct {
+	lock    sync.Mutex
+	order   []string
+	entries map[string]*Entry
+}
+
+// NewAggregator returns an empty Aggregator ready to be installed as the
+// process warning handler.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		entries: map[string]*Entry{},
+	}
+}
+
+// HandleWarningHeader implements rest.WarningHandler. Prefer installing the
+// Aggregator via rest.SetDefaultWarningHandlerWithContext so
+// HandleWarningHeaderWithContext is used instead and Verb/URL/Resource get
+// populated.
+func (a *Aggregator) HandleWarningHeader(code int, agent, text string) {
+	a.handle(requestInfo{}, code, agent, text)
+}
+
+// HandleWarningHeaderWithContext implements rest.WarningHandlerWithContext.
+// ctx is the request's context, which carries the verb and URL WrapTransport
+// stashed into it - the Warning response header itself has no way to
+// indicate which request produced it.
+func (a *Aggregator) HandleWarningHeaderWithContext(ctx context.Context, code int, agent, text string) {
+	info, _ := requestInfoFromContext(ctx)
+	a.handle(info, code, agent, text)
+}
+
+func (a *Aggregator) handle(info requestInfo, code int, agent, text string) {
+	if code != 299 || len(text) == 0 {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key := agent + "\x00" + text
+	if existing, ok := a.entries[key]; ok {
+		existing.Count++
+		return
+	}
+	entry := &Entry{Code: code, Agent: agent, Text: text, Count: 1, Verb: info.Verb, URL: info.URL}
+	if len(info.URL) > 0 {
+		entry.Resource = resourceFromURL(info.URL)
+	}
+	a.entries[key] = entry
+	a.order = append(a.order, key)
+}
+
+// Entries returns the buffered warnings in the order they were first seen.
+func (a *Aggregator) Entries() []Entry {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	out := make([]Entry, 0, len(a.order))
+	for _, key := range a.order {
+		out = append(out, *a.entries[key])
+	}
+	return out
+}
+
+// Len returns the number of distinct warnings observed so far.
+func (a *Aggregator) Len() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return len(a.order)
+}
@@ -0,0 +1,99 @@
+package warnings
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAggregatorHandleWarningHeaderWithContext(t *testing.T) {
+	a := NewAggregator()
+
+	req, err := http.NewRequest("PUT", "https://api.example.com:6443/apis/apps/v1/namespaces/default/deployments/myapp", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := withRequestInfo(req).Context()
+
+	a.HandleWarningHeaderWithContext(ctx, 299, "", "deprecated field used")
+
+	entries := a.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("len(Entries()) = %d, want 1", len(entries))
+	}
+	got := entries[0]
+	if got.Verb != "PUT" {
+		t.Errorf("Verb = %q, want PUT", got.Verb)
+	}
+	if got.URL != "https://api.example.com:6443/apis/apps/v1/namespaces/default/deployments/myapp" {
+		t.Errorf("URL = %q", got.URL)
+	}
+	if got.Resource != "apps/v1, resource=deployments" {
+		t.Errorf("Resource = %q, want apps/v1, resource=deployments", got.Resource)
+	}
+}
+
+func TestAggregatorHandleWarningHeaderWithoutContext(t *testing.T) {
+	a := NewAggregator()
+	a.HandleWarningHeader(299, "", "deprecated field used")
+
+	entries := a.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("len(Entries()) = %d, want 1", len(entries))
+	}
+	if entries[0].Verb != "" || entries[0].URL != "" {
+		t.Errorf("expected empty Verb/URL without a request context, got %+v", entries[0])
+	}
+}
+
+func TestAggregatorDedupesByAgentAndText(t *testing.T) {
+	a := NewAggregator()
+	a.HandleWarningHeader(299, "", "same warning")
+	a.HandleWarningHeader(299, "", "same warning")
+	a.HandleWarningHeader(299, "", "different warning")
+
+	entries := a.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("len(Entries()) = %d, want 2", len(entries))
+	}
+	if entries[0].Count != 2 {
+		t.Errorf("Count = %d, want 2", entries[0].Count)
+	}
+}
+
+func TestAggregatorIgnoresNonWarningCodes(t *testing.T) {
+	a := NewAggregator()
+	a.HandleWarningHeader(200, "", "not actually a warning")
+	if a.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", a.Len())
+	}
+}
+
+func TestMultiHandlerDispatchesContextToCapableHandlers(t *testing.T) {
+	aggregator := NewAggregator()
+	plain := &plainHandlerSpy{}
+	multi := NewMultiHandler(plain, aggregator)
+
+	req, err := http.NewRequest("GET", "https://api.example.com:6443/api/v1/namespaces/default/pods/mypod", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := withRequestInfo(req).Context()
+
+	multi.HandleWarningHeaderWithContext(ctx, 299, "", "warned")
+
+	if !plain.called {
+		t.Error("plain (context-unaware) handler was not called")
+	}
+	entries := aggregator.Entries()
+	if len(entries) != 1 || entries[0].Resource != "v1, resource=pods" {
+		t.Errorf("aggregator did not receive request context: %+v", entries)
+	}
+}
+
+type plainHandlerSpy struct {
+	called bool
+}
+
+func (s *plainHandlerSpy) HandleWarningHeader(code int, agent, text string) {
+	s.called = true
+}
@@ -0,0 +1,27 @@
+package warnings
+
+import (
+	"context"
+	"net/http"
+)
+
+// requestInfo is what WrapTransport stashes into a request's context so a
+// later HandleWarningHeaderWithContext call - which the API machinery
+// invokes with that same context - can recover which request the warning
+// came from. The Warning response header itself carries none of this.
+type requestInfo struct {
+	Verb string
+	URL  string
+}
+
+type requestInfoKey struct{}
+
+func withRequestInfo(req *http.Request) *http.Request {
+	info := requestInfo{Verb: req.Method, URL: req.URL.String()}
+	return req.WithContext(context.WithValue(req.Context(), requestInfoKey{}, info))
+}
+
+func requestInfoFromContext(ctx context.Context) (requestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(requestInfo)
+	return info, ok
+}
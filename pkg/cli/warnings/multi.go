@@ -0,0 +1,35 @@
+package warnings
+
+import (
+	"context"
+
+	"k8s.io/client-go/rest"
+)
+
+// multiHandler fans a single warning out to several rest.WarningHandlers,
+// e.g. the human-readable stream and the Aggregator used for structured
+// output.
+type multiHandler []rest.WarningHandler
+
+// NewMultiHandler returns a rest.WarningHandlerWithContext (which also
+// satisfies the older rest.WarningHandler) that forwards every warning to
+// each of handlers in order. A handler that itself implements
+// rest.WarningHandlerWithContext is called that way so it sees the request
+// context; others fall back to the plain interface.
+func NewMultiHandler(handlers ...rest.WarningHandler) rest.WarningHandlerWithContext {
+	return multiHandler(handlers)
+}
+
+func (m multiHandler) HandleWarningHeader(code int, agent, text string) {
+	m.HandleWarningHeaderWithContext(context.Background(), code, agent, text)
+}
+
+func (m multiHandler) HandleWarningHeaderWithContext(ctx context.Context, code int, agent, text string) {
+	for _, handler := range m {
+		if withContext, ok := handler.(rest.WarningHandlerWithContext); ok {
+			withContext.HandleWarningHeaderWithContext(ctx, code, agent, text)
+			continue
+		}
+		handler.HandleWarningHeader(code, agent, text)
+	}
+}
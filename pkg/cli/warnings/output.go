@@ -0,0 +1,49 @@
+package warnings
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ValidOutputFormats are the values accepted by --warnings-output.
+var ValidOutputFormats = []string{"text", "json", "yaml"}
+
+// Document is the structured form written for --warnings-output=json|yaml.
+type Document struct {
+	Warnings []Entry `json:"warnings" yaml:"warnings"`
+}
+
+// WriteStructured renders the aggregator's entries in the requested format.
+// format must be "json" or "yaml"; any other value is a programmer error.
+func WriteStructured(w io.Writer, format string, a *Aggregator) error {
+	doc := Document{Warnings: a.Entries()}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case "yaml":
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return fmt.Errorf("unknown warnings output format %q", format)
+	}
+}
+
+// IsValidOutputFormat returns whether format is one of ValidOutputFormats.
+func IsValidOutputFormat(format string) bool {
+	for _, valid := range ValidOutputFormats {
+		if format == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package warnings
+
+import (
+	"net/url"
+	"strings"
+)
+
+// resourceFromURL best-effort parses a Kubernetes API request path into its
+// group/version/resource, e.g. "/apis/apps/v1/namespaces/ns/deployments/x"
+// -> "apps/v1, resource=deployments" and "/api/v1/namespaces/ns/pods" ->
+// "v1, resource=pods". It returns "" if requestURL doesn't look like one of
+// the two API path shapes. This yields the resource (plural), not the
+// Kind - recovering the Kind would need a RESTMapping lookup this package
+// has no access to from a bare http.RoundTripper.
+func resourceFromURL(requestURL string) string {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		// /api/<version>/(namespaces/<ns>/)?<resource>/...
+		return gvrString("", segments[1], segments[2:])
+	case len(segments) >= 3 && segments[0] == "apis":
+		// /apis/<group>/<version>/(namespaces/<ns>/)?<resource>/...
+		return gvrString(segments[1], segments[2], segments[3:])
+	default:
+		return ""
+	}
+}
+
+func gvrString(group, version string, rest []string) string {
+	if len(rest) >= 2 && rest[0] == "namespaces" {
+		rest = rest[2:]
+	}
+	if len(rest) == 0 {
+		return ""
+	}
+	resource := rest[0]
+
+	gv := version
+	if len(group) > 0 {
+		gv = group + "/" + version
+	}
+	return gv + ", resource=" + resource
+}
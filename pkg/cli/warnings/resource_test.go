@@ -0,0 +1,50 @@
+package warnings
+
+import "testing"
+
+func TestResourceFromURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "core namespaced resource",
+			url:  "https://api.example.com:6443/api/v1/namespaces/default/pods/mypod",
+			want: "v1, resource=pods",
+		},
+		{
+			name: "core cluster-scoped resource",
+			url:  "https://api.example.com:6443/api/v1/nodes/my-node",
+			want: "v1, resource=nodes",
+		},
+		{
+			name: "grouped namespaced resource",
+			url:  "https://api.example.com:6443/apis/apps/v1/namespaces/default/deployments/myapp",
+			want: "apps/v1, resource=deployments",
+		},
+		{
+			name: "grouped cluster-scoped resource",
+			url:  "https://api.example.com:6443/apis/rbac.authorization.k8s.io/v1/clusterroles/admin",
+			want: "rbac.authorization.k8s.io/v1, resource=clusterroles",
+		},
+		{
+			name: "not an API request path",
+			url:  "https://api.example.com:6443/healthz",
+			want: "",
+		},
+		{
+			name: "not a URL at all",
+			url:  "://not a url",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourceFromURL(tt.url); got != tt.want {
+				t.Errorf("resourceFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
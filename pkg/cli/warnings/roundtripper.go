@@ -0,0 +1,21 @@
+package warnings
+
+import "net/http"
+
+// WrapTransport returns a RoundTripper wrapping rt that stashes each
+// request's verb and URL into its context. Install it via
+// genericclioptions.ConfigFlags.WrapConfigFn (or rest.Config.WrapTransport
+// directly) so Aggregator.HandleWarningHeaderWithContext can recover which
+// request produced a given warning - the Warning response header carries
+// no indication of that on its own.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &requestInfoTransport{rt: rt}
+}
+
+type requestInfoTransport struct {
+	rt http.RoundTripper
+}
+
+func (t *requestInfoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.rt.RoundTrip(withRequestInfo(req))
+}
@@ -0,0 +1,40 @@
+package warnings
+
+import (
+	"net/http"
+	"testing"
+)
+
+type recordingRoundTripper struct {
+	gotVerb string
+	gotURL  string
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	info, ok := requestInfoFromContext(req.Context())
+	if ok {
+		rt.gotVerb = info.Verb
+		rt.gotURL = info.URL
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestWrapTransportStashesRequestInfo(t *testing.T) {
+	inner := &recordingRoundTripper{}
+	wrapped := WrapTransport(inner)
+
+	req, err := http.NewRequest("PATCH", "https://api.example.com:6443/api/v1/namespaces/default/pods/mypod", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := wrapped.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if inner.gotVerb != "PATCH" {
+		t.Errorf("recorded Verb = %q, want PATCH", inner.gotVerb)
+	}
+	if inner.gotURL != "https://api.example.com:6443/api/v1/namespaces/default/pods/mypod" {
+		t.Errorf("recorded URL = %q", inner.gotURL)
+	}
+}